@@ -1,22 +1,60 @@
 package generator
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Address represent an address
 type Address struct {
-	Address    string `json:"address,omitempty" validate:"required"`
-	Address2   string `json:"address_2,omitempty"`
-	PostalCode string `json:"postal_code,omitempty"`
-	City       string `json:"city,omitempty"`
-	Country    string `json:"country,omitempty"`
-	BusinessID string
-	TaxID      string
-	VAT        string
-	IBAN       string
-	BankName   string
+	Address     string `json:"address,omitempty" validate:"required"`
+	Address2    string `json:"address_2,omitempty"`
+	PostalCode  string `json:"postal_code,omitempty"`
+	City        string `json:"city,omitempty"`
+	Country     string `json:"country,omitempty"`
+	CountryCode string `json:"country_code,omitempty" validate:"omitempty,len=2"` // ISO 3166-1 alpha-2 code ex SK
+	BusinessID  string // National company ID ex IČO, SIREN, KVK
+	TaxID       string
+	VAT         string `validate:"omitempty,vat"`
+
+	// IBAN of the contact bank account
+	//
+	// Deprecated: use Contact.BankAccounts, the IBAN is shown as a bank account when the contact has none
+	IBAN string
+
+	// BankName of the contact bank account
+	//
+	// Deprecated: use Contact.BankAccounts, the bank name is shown with the IBAN when the contact has no bank account
+	BankName string
+}
+
+// countryCode return the address country code, falling back to the VAT number prefix
+func (a *Address) countryCode() string {
+	if len(a.CountryCode) > 0 {
+		return strings.ToUpper(a.CountryCode)
+	}
+
+	switch country := vatCountry(a.VAT); country {
+	case "EL":
+		return "GR"
+	case "XI":
+		return "GB"
+	default:
+		return country
+	}
+}
+
+func (a *Address) businessIDTitle(options *Options) string {
+	if title, ok := options.TextBusinessIDTitles[a.countryCode()]; ok {
+		return title
+	}
+
+	return options.TextBusinessIDTitle
 }
 
 // ToString output address as string
 // Line break are added for new lines
-func (a *Address) lines() []string {
+func (a *Address) lines(options *Options) []string {
 	res := []string{
 		a.Address,
 	}
@@ -30,19 +68,13 @@ func (a *Address) lines() []string {
 		res = append(res, a.Country)
 	}
 	if len(a.BusinessID) > 0 {
-		res = append(res, a.BusinessID)
+		res = append(res, fmt.Sprintf("%s: %s", a.businessIDTitle(options), a.BusinessID))
 	}
 	if len(a.TaxID) > 0 {
-		res = append(res, a.TaxID)
+		res = append(res, fmt.Sprintf("%s: %s", options.TextTaxIDTitle, a.TaxID))
 	}
 	if len(a.VAT) > 0 {
-		res = append(res, a.VAT)
-	}
-	if len(a.IBAN) > 0 {
-		res = append(res, a.IBAN)
-	}
-	if len(a.BankName) > 0 {
-		res = append(res, a.BankName)
+		res = append(res, fmt.Sprintf("%s: %s", options.TextVATTitle, a.VAT))
 	}
 
 	return res
@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// BankAccount define a bank account the document can be paid to
+type BankAccount struct {
+	IBAN     string `json:"iban,omitempty" validate:"required,iban"`
+	BIC      string `json:"bic,omitempty" validate:"omitempty,bic"`
+	BankName string `json:"bank_name,omitempty"`
+	Currency string `json:"currency,omitempty" validate:"omitempty,len=3"`
+	Show     bool   `json:"show,omitempty"` // Show the account on the document
+}
+
+// ibanLengths define the IBAN length of each country using it
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16,
+	"BG": 22, "BH": 22, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28,
+	"CZ": 24, "DE": 22, "DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24,
+	"FI": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18,
+	"GR": 27, "GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23,
+	"IS": 26, "IT": 27, "JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32,
+	"LI": 21, "LT": 20, "LU": 20, "LV": 21, "MC": 27, "MD": 24, "ME": 22,
+	"MK": 19, "MR": 27, "MT": 31, "MU": 30, "NL": 18, "NO": 15, "PK": 24,
+	"PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "SA": 24,
+	"SC": 31, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20,
+}
+
+var (
+	ibanRegexp = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}$`)
+	bicRegexp  = regexp.MustCompile(`^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+)
+
+// normalizeIBAN remove spaces and uppercase an IBAN
+func normalizeIBAN(iban string) string {
+	return strings.ToUpper(strings.ReplaceAll(iban, " ", ""))
+}
+
+// isValidIBAN check IBAN format, country length and mod-97 checksum
+func isValidIBAN(iban string) bool {
+	iban = normalizeIBAN(iban)
+	if !ibanRegexp.MatchString(iban) {
+		return false
+	}
+
+	if length, ok := ibanLengths[iban[:2]]; ok && len(iban) != length {
+		return false
+	}
+
+	// Move country code and check digits to the end, then convert letters to numbers (A=10 ... Z=35)
+	var digits strings.Builder
+	for _, r := range iban[4:] + iban[:4] {
+		if r >= 'A' && r <= 'Z' {
+			digits.WriteString(fmt.Sprint(r - 'A' + 10))
+		} else {
+			digits.WriteRune(r)
+		}
+	}
+
+	number, ok := new(big.Int).SetString(digits.String(), 10)
+	if !ok {
+		return false
+	}
+
+	return new(big.Int).Mod(number, big.NewInt(97)).Int64() == 1
+}
+
+// isValidBIC check BIC/SWIFT code format (8 or 11 characters)
+func isValidBIC(bic string) bool {
+	return bicRegexp.MatchString(bic)
+}
+
+// formattedIBAN return the IBAN in groups of four characters
+func (b *BankAccount) formattedIBAN() string {
+	iban := normalizeIBAN(b.IBAN)
+
+	var groups []string
+	for len(iban) > 4 {
+		groups = append(groups, iban[:4])
+		iban = iban[4:]
+	}
+	groups = append(groups, iban)
+
+	return strings.Join(groups, " ")
+}
+
+func (b *BankAccount) lines(options *Options) []string {
+	res := []string{
+		fmt.Sprintf("%s: %s", options.TextIBANTitle, b.formattedIBAN()),
+	}
+	if len(b.BIC) > 0 {
+		res = append(res, fmt.Sprintf("%s: %s", options.TextBICTitle, b.BIC))
+	}
+	if len(b.BankName) > 0 {
+		res = append(res, fmt.Sprintf("%s: %s", options.TextBankNameTitle, b.BankName))
+	}
+	if len(b.Currency) > 0 {
+		res = append(res, fmt.Sprintf("%s: %s", options.TextCurrencyTitle, b.Currency))
+	}
+
+	return res
+}
@@ -0,0 +1,103 @@
+package generator
+
+import "testing"
+
+func TestIsValidIBAN(t *testing.T) {
+	cases := map[string]bool{
+		"FR1420041010050500013M02606":  true,
+		"DE89370400440532013000":       true,
+		"de89 3704 0044 0532 0130 00":  true,
+		"GB82WEST12345698765432":       true,
+		"SK3112000000198742637541":     true,
+		"DE89370400440532013001":       false,
+		"DE8937040044053201300":        false,
+		"HU1200005432503454350":        false,
+		"XX":                           false,
+		"FR14200410100505000 13M0260!": false,
+	}
+
+	for iban, expected := range cases {
+		if got := isValidIBAN(iban); got != expected {
+			t.Errorf("isValidIBAN(%q) = %v, expected %v", iban, got, expected)
+		}
+	}
+}
+
+func TestIsValidBIC(t *testing.T) {
+	cases := map[string]bool{
+		"PSSTFRPPLIL": true,
+		"DEUTDEFF":    true,
+		"DEUTDEFF500": true,
+		"DEUTDEF":     false,
+		"deutdeff":    false,
+		"1EUTDEFF":    false,
+	}
+
+	for bic, expected := range cases {
+		if got := isValidBIC(bic); got != expected {
+			t.Errorf("isValidBIC(%q) = %v, expected %v", bic, got, expected)
+		}
+	}
+}
+
+func TestValidateBankAccounts(t *testing.T) {
+	doc, _ := New(Invoice, &Options{})
+	doc.SetRef("ref")
+	doc.SetCompany(&Contact{
+		Name:    "Company",
+		Address: &Address{Address: "Street 1"},
+		BankAccounts: []*BankAccount{
+			{IBAN: "DE89370400440532013000", BIC: "DEUTDEFF"},
+		},
+	})
+	doc.SetCustomer(&Contact{Name: "Customer"})
+
+	if err := doc.Validate(); err != nil {
+		t.Errorf("unexpected validation error: %s", err)
+	}
+
+	doc.Company.BankAccounts[0].IBAN = "DE89370400440532013001"
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for invalid IBAN")
+	}
+}
+
+func TestBankAccounts(t *testing.T) {
+	doc := newTestDocument()
+	doc.SetCompany(&Contact{
+		Name:    "Company",
+		Address: &Address{Address: "Street 1"},
+		BankAccounts: []*BankAccount{
+			{IBAN: "FR1420041010050500013M02606", BIC: "PSSTFRPPLIL", BankName: "MehMeh bank", Currency: "EUR", Show: true},
+			{IBAN: "DE89370400440532013000", Currency: "USD"},
+		},
+	})
+	doc.AppendItem(&Item{Name: "Service", UnitCost: "10", Quantity: "1"})
+
+	if _, err := doc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+	if accounts := doc.Company.bankAccounts(); len(accounts) != 2 || accounts[0].IBAN != "FR1420041010050500013M02606" {
+		t.Errorf("expected the contact bank accounts, got %d", len(accounts))
+	}
+}
+
+func TestDeprecatedAddressBankAccount(t *testing.T) {
+	contact := &Contact{Name: "Company", Address: &Address{Address: "Street 1", IBAN: "HU1200005432503454350", BankName: "MehMeh bank"}}
+
+	accounts := contact.bankAccounts()
+	if len(accounts) != 1 || accounts[0].IBAN != "HU1200005432503454350" || accounts[0].BankName != "MehMeh bank" || !accounts[0].Show {
+		t.Fatalf("expected the address IBAN and bank name as a shown bank account")
+	}
+
+	contact.BankAccounts = []*BankAccount{{IBAN: "DE89370400440532013000", Show: true}}
+	if accounts := contact.bankAccounts(); len(accounts) != 1 || accounts[0].IBAN != "DE89370400440532013000" {
+		t.Errorf("expected contact bank accounts to replace the address IBAN")
+	}
+
+	doc := newTestDocument()
+	doc.SetCompany(&Contact{Name: "Company", Address: &Address{Address: "Street 1", IBAN: "HU1200005432503454350", BankName: "MehMeh bank"}})
+	if _, err := doc.Build(); err != nil {
+		t.Errorf("unexpected build error with deprecated address bank fields: %s", err)
+	}
+}
@@ -0,0 +1,305 @@
+package generator
+
+import (
+	"unicode"
+)
+
+// bidiType define the simplified bidirectional class of a rune
+type bidiType int
+
+const (
+	bidiNeutral bidiType = iota
+	bidiLTR
+	bidiRTL
+	bidiNumber
+)
+
+// arabicForms define the isolated, final, initial and medial presentation forms of arabic letters.
+// Letters with only two forms join on their right side only.
+var arabicForms = map[rune][]rune{
+	0x0621: {0xFE80},
+	0x0622: {0xFE81, 0xFE82},
+	0x0623: {0xFE83, 0xFE84},
+	0x0624: {0xFE85, 0xFE86},
+	0x0625: {0xFE87, 0xFE88},
+	0x0626: {0xFE89, 0xFE8A, 0xFE8B, 0xFE8C},
+	0x0627: {0xFE8D, 0xFE8E},
+	0x0628: {0xFE8F, 0xFE90, 0xFE91, 0xFE92},
+	0x0629: {0xFE93, 0xFE94},
+	0x062A: {0xFE95, 0xFE96, 0xFE97, 0xFE98},
+	0x062B: {0xFE99, 0xFE9A, 0xFE9B, 0xFE9C},
+	0x062C: {0xFE9D, 0xFE9E, 0xFE9F, 0xFEA0},
+	0x062D: {0xFEA1, 0xFEA2, 0xFEA3, 0xFEA4},
+	0x062E: {0xFEA5, 0xFEA6, 0xFEA7, 0xFEA8},
+	0x062F: {0xFEA9, 0xFEAA},
+	0x0630: {0xFEAB, 0xFEAC},
+	0x0631: {0xFEAD, 0xFEAE},
+	0x0632: {0xFEAF, 0xFEB0},
+	0x0633: {0xFEB1, 0xFEB2, 0xFEB3, 0xFEB4},
+	0x0634: {0xFEB5, 0xFEB6, 0xFEB7, 0xFEB8},
+	0x0635: {0xFEB9, 0xFEBA, 0xFEBB, 0xFEBC},
+	0x0636: {0xFEBD, 0xFEBE, 0xFEBF, 0xFEC0},
+	0x0637: {0xFEC1, 0xFEC2, 0xFEC3, 0xFEC4},
+	0x0638: {0xFEC5, 0xFEC6, 0xFEC7, 0xFEC8},
+	0x0639: {0xFEC9, 0xFECA, 0xFECB, 0xFECC},
+	0x063A: {0xFECD, 0xFECE, 0xFECF, 0xFED0},
+	0x0641: {0xFED1, 0xFED2, 0xFED3, 0xFED4},
+	0x0642: {0xFED5, 0xFED6, 0xFED7, 0xFED8},
+	0x0643: {0xFED9, 0xFEDA, 0xFEDB, 0xFEDC},
+	0x0644: {0xFEDD, 0xFEDE, 0xFEDF, 0xFEE0},
+	0x0645: {0xFEE1, 0xFEE2, 0xFEE3, 0xFEE4},
+	0x0646: {0xFEE5, 0xFEE6, 0xFEE7, 0xFEE8},
+	0x0647: {0xFEE9, 0xFEEA, 0xFEEB, 0xFEEC},
+	0x0648: {0xFEED, 0xFEEE},
+	0x0649: {0xFEEF, 0xFEF0},
+	0x064A: {0xFEF1, 0xFEF2, 0xFEF3, 0xFEF4},
+}
+
+// lamAlefForms define the isolated and final forms of the lam alef ligatures, by alef
+var lamAlefForms = map[rune][]rune{
+	0x0622: {0xFEF5, 0xFEF6},
+	0x0623: {0xFEF7, 0xFEF8},
+	0x0625: {0xFEF9, 0xFEFA},
+	0x0627: {0xFEFB, 0xFEFC},
+}
+
+// mirroredBrackets define the glyphs swapped in right to left runs
+var mirroredBrackets = map[rune]rune{
+	'(': ')', ')': '(',
+	'[': ']', ']': '[',
+	'{': '}', '}': '{',
+	'<': '>', '>': '<',
+}
+
+const arabicTatweel = 0x0640
+
+func isRTLRune(r rune) bool {
+	return r >= 0x0590 && r <= 0x08FF && !isArabicDigit(r) ||
+		r >= 0xFB1D && r <= 0xFDFF ||
+		r >= 0xFE70 && r <= 0xFEFF
+}
+
+func isArabicDigit(r rune) bool {
+	return r >= 0x0660 && r <= 0x0669 || r >= 0x06F0 && r <= 0x06F9
+}
+
+func isArabicMark(r rune) bool {
+	return r >= 0x064B && r <= 0x065F || r == 0x0670
+}
+
+func runeBidiType(r rune) bidiType {
+	switch {
+	case unicode.IsDigit(r) || isArabicDigit(r):
+		return bidiNumber
+	case isRTLRune(r):
+		return bidiRTL
+	case unicode.IsLetter(r):
+		return bidiLTR
+	}
+
+	return bidiNeutral
+}
+
+// hasRTL return true when the text contains right to left runes
+func hasRTL(text string) bool {
+	for _, r := range text {
+		if isRTLRune(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// joinsLeft return true when the arabic letter joins the following letter
+func joinsLeft(r rune) bool {
+	forms, ok := arabicForms[r]
+	return ok && len(forms) == 4 || r == arabicTatweel
+}
+
+// joinsRight return true when the arabic letter joins the previous letter
+func joinsRight(r rune) bool {
+	forms, ok := arabicForms[r]
+	return ok && len(forms) > 1 || r == arabicTatweel
+}
+
+// shapeArabic replaces arabic letters, in logical order, by their contextual presentation forms
+func shapeArabic(chars []rune) []rune {
+	// Neighbour letter, skipping transparent marks
+	neighbour := func(i int, step int) rune {
+		for j := i + step; j >= 0 && j < len(chars); j += step {
+			if !isArabicMark(chars[j]) {
+				return chars[j]
+			}
+		}
+		return 0
+	}
+
+	shaped := make([]rune, 0, len(chars))
+	for i := 0; i < len(chars); i++ {
+		r := chars[i]
+		forms, ok := arabicForms[r]
+		if !ok {
+			shaped = append(shaped, r)
+			continue
+		}
+
+		previous, next := neighbour(i, -1), neighbour(i, 1)
+		joinedBefore := joinsLeft(previous)
+
+		// Lam alef ligature
+		if ligature, ok := lamAlefForms[next]; r == 0x0644 && ok && (i+1 < len(chars) && chars[i+1] == next) {
+			if joinedBefore {
+				shaped = append(shaped, ligature[1])
+			} else {
+				shaped = append(shaped, ligature[0])
+			}
+			i++
+			continue
+		}
+
+		joinedAfter := len(forms) == 4 && joinsRight(next)
+		switch {
+		case joinedBefore && joinedAfter:
+			shaped = append(shaped, forms[3])
+		case joinedAfter:
+			shaped = append(shaped, forms[2])
+		case joinedBefore && len(forms) > 1:
+			shaped = append(shaped, forms[1])
+		default:
+			shaped = append(shaped, forms[0])
+		}
+	}
+
+	return shaped
+}
+
+// bidiLevels return the embedding level of each rune of a paragraph in base direction,
+// a simplification of the unicode bidirectional algorithm: numbers keep a left to right order.
+func bidiLevels(chars []rune, rtl bool) []int {
+	types := make([]bidiType, len(chars))
+	for k, r := range chars {
+		types[k] = runeBidiType(r)
+	}
+
+	// Separators and currencies between or next to digits belong to the number
+	for k := range types {
+		if types[k] != bidiNeutral {
+			continue
+		}
+
+		before := k > 0 && types[k-1] == bidiNumber
+		after := k < len(types)-1 && runeBidiType(chars[k+1]) == bidiNumber
+		if before && after || unicode.Is(unicode.Sc, chars[k]) && (before || after) {
+			types[k] = bidiNumber
+		}
+	}
+
+	base := bidiLTR
+	baseLevel := 0
+	if rtl {
+		base = bidiRTL
+		baseLevel = 1
+	}
+
+	// Right to left runes are at level 1, left to right ones above it in a right to left paragraph
+	levelOf := func(t bidiType) int {
+		if t == bidiRTL {
+			return 1
+		}
+		return baseLevel * 2
+	}
+
+	levels := make([]int, len(chars))
+	previousStrong := base
+	for k, t := range types {
+		switch t {
+		case bidiLTR, bidiRTL:
+			levels[k] = levelOf(t)
+			previousStrong = t
+		case bidiNumber:
+			levels[k] = 2
+			if !rtl && previousStrong == bidiLTR {
+				levels[k] = 0
+			}
+		}
+	}
+
+	// Neutrals take the direction of the surrounding strong runes when they agree, numbers count as right to left
+	strongAt := func(k int) bidiType {
+		if types[k] == bidiNumber {
+			return bidiRTL
+		}
+		return types[k]
+	}
+	for k := 0; k < len(types); k++ {
+		if types[k] != bidiNeutral {
+			continue
+		}
+
+		end := k
+		for end < len(types) && types[end] == bidiNeutral {
+			end++
+		}
+
+		before, after := base, base
+		if k > 0 {
+			before = strongAt(k - 1)
+		}
+		if end < len(types) {
+			after = strongAt(end)
+		}
+
+		direction := base
+		if before == after {
+			direction = before
+		}
+		for j := k; j < end; j++ {
+			levels[j] = levelOf(direction)
+		}
+		k = end - 1
+	}
+
+	return levels
+}
+
+// visualOrder return the text shaped and reordered for display, in a paragraph of base direction
+func visualOrder(text string, rtl bool) string {
+	if !rtl && !hasRTL(text) {
+		return text
+	}
+
+	chars := shapeArabic([]rune(text))
+	levels := bidiLevels(chars, rtl)
+
+	maxLevel := 0
+	for k, level := range levels {
+		if level > maxLevel {
+			maxLevel = level
+		}
+		if mirrored, ok := mirroredBrackets[chars[k]]; ok && level%2 == 1 {
+			chars[k] = mirrored
+		}
+	}
+
+	// Reverse every sequence at a level or higher, from the highest level to the lowest odd one
+	for level := maxLevel; level >= 1; level-- {
+		for start := 0; start < len(chars); start++ {
+			if levels[start] < level {
+				continue
+			}
+
+			end := start
+			for end < len(chars) && levels[end] >= level {
+				end++
+			}
+			for i, j := start, end-1; i < j; i, j = i+1, j-1 {
+				chars[i], chars[j] = chars[j], chars[i]
+				levels[i], levels[j] = levels[j], levels[i]
+			}
+			start = end
+		}
+	}
+
+	return string(chars)
+}
@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestVisualOrder(t *testing.T) {
+	expected := []struct {
+		text   string
+		rtl    bool
+		visual string
+	}{
+		{"Invoice 12", false, "Invoice 12"},
+		{"שלום", false, "םולש"},
+		{"Client שלום 123", false, "Client 123 םולש"},
+		{"מחיר: 1,200.50", true, "1,200.50 :ריחמ"},
+		{"(א)", true, "(א)"},
+		{"Invoice", true, "Invoice"},
+		{"سلام", false, "ﻡﻼﺳ"},
+		{"بيت", false, "ﺖﻴﺑ"},
+	}
+	for _, e := range expected {
+		if visual := visualOrder(e.text, e.rtl); visual != e.visual {
+			t.Errorf("%q: expected %q, got %q", e.text, e.visual, visual)
+		}
+	}
+}
+
+func TestRTLLayout(t *testing.T) {
+	doc, _ := New(Invoice, &Options{Direction: DirectionRTL})
+	if x := doc.drawX(doc.Options.MarginLeft, 100); x != doc.pageWidth()-doc.Options.MarginLeft-100 {
+		t.Errorf("expected mirrored x, got %v", x)
+	}
+	if align := mirrorAlign(8); align != 2 {
+		t.Errorf("expected left alignment to be mirrored to right, got %d", align)
+	}
+
+	doc = newFallbackDocument(t)
+	doc.Options.Direction = DirectionRTL
+	doc.SetRef("ref")
+	doc.SetNotes("ملاحظات (1)")
+	doc.SetCompany(&Contact{Name: "شركة", Address: &Address{Address: "شارع 12"}})
+	doc.SetCustomer(&Contact{Name: "לקוח", Address: &Address{Address: "רחוב 3"}})
+	doc.AppendItem(&Item{Name: "خدمة", Description: "وصف الخدمة", UnitCost: "1200.50", Quantity: "2"})
+
+	if _, err := doc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+
+	doc.Options.Direction = "up"
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for unknown direction")
+	}
+}
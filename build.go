@@ -3,9 +3,9 @@ package generator
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"time"
 
-	"github.com/leekchan/accounting"
 	"github.com/shopspring/decimal"
 	"github.com/signintech/gopdf"
 )
@@ -19,9 +19,9 @@ func (doc *Document) Build() (*gopdf.GoPdf, error) {
 	}
 
 	// Build base doc
-	doc.pdf.SetMargins(BaseMargin, BaseMarginTop, BaseMargin, 0)
-	doc.pdf.SetX(10)
-	doc.pdf.SetY(10)
+	doc.pdf.SetMargins(doc.Options.MarginLeft, doc.Options.MarginTop, doc.Options.MarginRight, doc.Options.MarginBottom)
+	doc.pdf.SetX(doc.Options.MarginLeft)
+	doc.pdf.SetY(doc.Options.MarginTop)
 	doc.pdf.SetTextColor(
 		doc.Options.BaseTextColor[0],
 		doc.Options.BaseTextColor[1],
@@ -47,10 +47,10 @@ func (doc *Document) Build() (*gopdf.GoPdf, error) {
 	}
 
 	// Add first page
-	doc.pdf.AddPage()
+	doc.addPage()
 
 	// Load font
-	doc.pdf.SetFont("Ubuntu", "", 12)
+	doc.setFont("", 12)
 
 	// Appenf document title
 	doc.appendTitle()
@@ -58,6 +58,9 @@ func (doc *Document) Build() (*gopdf.GoPdf, error) {
 	// Appenf document metas (ref & version)
 	doc.appendMetas()
 
+	// Append status stamp
+	doc.appendStamp()
+
 	// Append company contact to doc
 	companyBottom := doc.Company.appendCompanyContactToDoc(doc)
 
@@ -65,37 +68,48 @@ func (doc *Document) Build() (*gopdf.GoPdf, error) {
 	customerBottom := doc.Customer.appendCustomerContactToDoc(doc)
 
 	if customerBottom > companyBottom {
-		doc.pdf.SetX(10)
+		doc.pdf.SetX(doc.Options.MarginLeft)
 		doc.pdf.SetY(customerBottom)
 	} else {
-		doc.pdf.SetX(10)
+		doc.pdf.SetX(doc.Options.MarginLeft)
 		doc.pdf.SetY(companyBottom)
 	}
 
 	// Append description
 	doc.appendDescription()
 
-	// Append items
-	doc.appendItems()
-
-	// Check page height (total bloc height = 30, 45 when doc discount)
-	offset := doc.pdf.GetY() + 30
-	if doc.Discount != nil {
-		offset += 15
+	// Append items, open invoices of a reminder or ledger entries of a statement
+	if doc.Type == Reminder {
+		doc.appendOpenInvoices()
+	} else if doc.Type == Statement {
+		doc.appendLedgerEntries()
+	} else {
+		doc.appendItems()
 	}
-	if offset > MaxPageHeight {
-		doc.pdf.AddPage()
+
+	// Keep notes and totals together
+	if doc.pdf.GetY()+doc.totalsHeight() > doc.contentBottom() {
+		doc.addPage()
 	}
 
 	// Append notes
 	doc.appendNotes()
 
 	// Append total
-	doc.appendTotal()
+	if doc.Type == Reminder {
+		doc.appendReminderTotal()
+	} else if doc.Type == Statement {
+		doc.appendStatementTotal()
+	} else {
+		doc.appendTotal()
+	}
 
 	// Append payment term
 	doc.appendPaymentTerm()
 
+	// Append tax exemption reasons
+	doc.appendTaxExemptions()
+
 	return doc.pdf, nil
 }
 
@@ -110,141 +124,165 @@ func (doc *Document) appendTitle() {
 	// Draw rect
 	doc.pdf.SetFillColor(doc.Options.DarkBgColor[0], doc.Options.DarkBgColor[1], doc.Options.DarkBgColor[2])
 	doc.pdf.SetStrokeColor(doc.Options.DarkBgColor[0], doc.Options.DarkBgColor[1], doc.Options.DarkBgColor[2])
-	doc.pdf.Rectangle(PageWidth-BaseMargin-ColumnWidth, BaseMarginTop, PageWidth-BaseMargin, BaseMarginTop+titleFontSize+titleMargin, "F", 0, 0)
+	doc.rectangle(doc.rightColumnX(), doc.Options.MarginTop, doc.contentRight(), doc.Options.MarginTop+titleFontSize+titleMargin, "F")
 
 	// Set x y
-	doc.pdf.SetX(PageWidth - BaseMargin - ColumnWidth)
-	doc.pdf.SetY(BaseMarginTop + titleMargin/2)
+	doc.pdf.SetX(doc.rightColumnX())
+	doc.pdf.SetY(doc.Options.MarginTop + titleMargin/2)
 
 	// Draw text
-	doc.pdf.SetFont("Ubuntu", "", titleFontSize)
-	doc.pdf.CellWithOption(&gopdf.Rect{W: ColumnWidth, H: titleFontSize}, title, gopdf.CellOption{Align: gopdf.Center})
+	doc.setFont("", titleFontSize)
+	doc.cellWithOption(&gopdf.Rect{W: doc.columnWidth(), H: titleFontSize}, title, gopdf.CellOption{Align: gopdf.Center})
 }
 
 func (doc *Document) appendMetas() {
 	// Append ref
 	refString := fmt.Sprintf("%s: %s", doc.Options.TextRefTitle, doc.Ref)
-	const (
-		top = BaseMarginTop + titleFontSize + titleMargin + 1
-	)
+	top := doc.Options.MarginTop + titleFontSize + titleMargin + 1
 
-	doc.pdf.SetX(PageWidth - BaseMargin - ColumnWidth)
+	doc.pdf.SetX(doc.rightColumnX())
 	doc.pdf.SetY(top)
-	doc.pdf.SetFont("Ubuntu", "", metasFontSize)
-	doc.pdf.CellWithOption(&gopdf.Rect{W: ColumnWidth, H: metasFontSize}, refString, gopdf.CellOption{Align: gopdf.Right})
+	doc.setFont("", metasFontSize)
+	doc.cellWithOption(&gopdf.Rect{W: doc.columnWidth(), H: metasFontSize}, refString, gopdf.CellOption{Align: gopdf.Right})
 
 	// Append version
 	if len(doc.Version) > 0 {
 		versionString := fmt.Sprintf("%s: %s", doc.Options.TextVersionTitle, doc.Version)
-		doc.pdf.SetX(PageWidth - BaseMargin - ColumnWidth)
+		doc.pdf.SetX(doc.rightColumnX())
 		doc.pdf.SetY(top + metasFontSize)
-		doc.pdf.SetFont("Ubuntu", "", metasFontSize)
-		doc.pdf.CellWithOption(&gopdf.Rect{W: ColumnWidth, H: metasFontSize}, versionString, gopdf.CellOption{Align: gopdf.Right})
+		doc.setFont("", metasFontSize)
+		doc.cellWithOption(&gopdf.Rect{W: doc.columnWidth(), H: metasFontSize}, versionString, gopdf.CellOption{Align: gopdf.Right})
 	}
 
 	// Append date
-	date := time.Now().Format("02/01/2006")
+	date := time.Now().Format(doc.Options.DateFormat)
 	if len(doc.Date) > 0 {
 		date = doc.Date
 	}
 	dateString := fmt.Sprintf("%s: %s", doc.Options.TextDateTitle, date)
-	doc.pdf.SetX(PageWidth - BaseMargin - ColumnWidth)
+	doc.pdf.SetX(doc.rightColumnX())
 	doc.pdf.SetY(top + metasFontSize*2)
-	doc.pdf.SetFont("Ubuntu", "", metasFontSize)
-	doc.pdf.CellWithOption(&gopdf.Rect{W: ColumnWidth, H: metasFontSize}, dateString, gopdf.CellOption{Align: gopdf.Right})
+	doc.setFont("", metasFontSize)
+	doc.cellWithOption(&gopdf.Rect{W: doc.columnWidth(), H: metasFontSize}, dateString, gopdf.CellOption{Align: gopdf.Right})
 }
 
 func (doc *Document) appendDescription() {
 	if len(doc.Description) > 0 {
 		doc.pdf.SetY(doc.pdf.GetY() + 10)
-		doc.pdf.SetFont("Ubuntu", "", 10)
-		doc.pdf.MultiCell(&gopdf.Rect{W: 190, H: 5}, doc.Description)
+		doc.setFont("", 10)
+		doc.multiCell(&gopdf.Rect{W: doc.contentWidth(), H: 5}, doc.Description)
 	}
 }
 
-func (doc *Document) drawsTableTitles() {
+func (doc *Document) drawsTableTitles(columns []*itemColumnBox) {
 	// Draw rec
 	doc.pdf.SetStrokeColor(doc.Options.GreyBgColor[0], doc.Options.GreyBgColor[1], doc.Options.GreyBgColor[2])
 	doc.pdf.SetFillColor(doc.Options.GreyBgColor[0], doc.Options.GreyBgColor[1], doc.Options.GreyBgColor[2])
-	doc.pdf.Rectangle(
-		BaseMargin,
+	doc.rectangle(
+		doc.Options.MarginLeft,
 		doc.pdf.GetY(),
-		PageWidth-BaseMargin,
+		doc.contentRight(),
 		doc.pdf.GetY()+itemFontSize+itemTitleMargin,
 		"F",
-		0,
-		0,
 	)
 
 	// Draw table titles
-	doc.pdf.SetX(BaseMargin)
+	doc.pdf.SetX(doc.Options.MarginLeft)
 	doc.pdf.SetY(doc.pdf.GetY() + itemTitleMargin/2)
-	doc.pdf.SetFont("Ubuntu", "B", itemFontSize)
-
-	// Name
-	doc.pdf.SetX(BaseMargin + itemTitleMargin)
-	doc.pdf.Cell(nil, doc.Options.TextItemsNameTitle)
-
-	// Unit price
-	doc.pdf.SetX(ItemColUnitPriceOffset)
-	doc.pdf.Cell(
-		&gopdf.Rect{
-			W: ItemColQuantityOffset - ItemColUnitPriceOffset,
-			H: itemFontSize + itemTitleMargin,
-		},
-		doc.Options.TextItemsUnitCostTitle,
-	)
+	doc.setFont("B", itemFontSize)
+
+	for _, box := range columns {
+		doc.pdf.SetX(box.textX())
+		doc.cellWithOption(
+			&gopdf.Rect{W: box.textW(), H: itemFontSize},
+			box.column.title(doc.Options),
+			gopdf.CellOption{Align: box.column.align() | gopdf.Top},
+		)
+	}
+}
 
-	// Quantity
-	doc.pdf.SetX(ItemColQuantityOffset)
-	doc.pdf.Cell(nil, doc.Options.TextItemsQuantityTitle)
+func (doc *Document) appendItems() {
+	for _, item := range doc.lineItems() {
+		// Check item tax
+		if item.Tax == nil && len(item.Taxes) == 0 {
+			item.Tax = doc.DefaultTax
+		}
+	}
 
-	// Total HT
-	doc.pdf.SetX(ItemColTotalHTOffset)
-	doc.pdf.Cell(nil, doc.Options.TextItemsTotalHTTitle)
+	columns := doc.itemColumnBoxes()
 
-	// Tax
-	doc.pdf.SetX(ItemColTaxOffset)
-	doc.pdf.Cell(nil, doc.Options.TextItemsTaxTitle)
+	doc.pdf.SetY(doc.pdf.GetY() + itemsPaddingTop)
+	doc.drawsTableTitles(columns)
 
-	// Discount
-	doc.pdf.SetX(ItemColDiscountOffset)
-	doc.pdf.Cell(nil, doc.Options.TextItemsDiscountTitle)
+	doc.pdf.SetX(doc.Options.MarginLeft)
+	doc.pdf.SetY(doc.pdf.GetY() + itemFontSize + itemTitleMargin)
+	doc.setFont("", itemFontSize)
 
-	// TOTAL TTC
-	doc.pdf.SetX(ItemColTotalTTCOffset)
-	doc.pdf.Cell(nil, doc.Options.TextItemsTotalTTCTitle)
+	for k, item := range doc.Items {
+		// Whole lines are moved to the next page
+		doc.itemsPageBreak(columns, doc.itemsKeepHeight(k, columns))
+
+		// Append to pdf
+		switch item.Type {
+		case ItemHeading:
+			doc.appendSectionHeading(item)
+		case ItemSubtotal:
+			doc.appendSectionSubtotal(item, doc.sectionItems(k), columns)
+		default:
+			item.appendColTo(doc.Options, doc, columns)
+			item.appendChildrenTo(doc, columns, k == len(doc.Items)-1)
+		}
+
+		doc.pdf.SetY(doc.pdf.GetY() + itemsMargin)
+	}
 }
 
-func (doc *Document) appendItems() {
-	doc.pdf.SetY(doc.pdf.GetY() + itemsPaddingTop)
-	doc.drawsTableTitles()
+// itemsTop return the y of the first line of the items table on a new page
+func (doc *Document) itemsTop() float64 {
+	return doc.Options.MarginTop + itemTitleMargin/2 + itemFontSize + itemTitleMargin
+}
 
-	doc.pdf.SetX(BaseMargin)
-	doc.pdf.SetY(doc.pdf.GetY() + itemFontSize + itemTitleMargin)
-	doc.pdf.SetFont("Ubuntu", "", itemFontSize)
+// itemsRowHeight return the height of an items table line, section headings and subtotals included
+func (doc *Document) itemsRowHeight(item *Item, columns []*itemColumnBox) float64 {
+	if !item.isLine() {
+		return doc.sectionHeight(item, columns)
+	}
 
-	for i := 0; i < len(doc.Items); i++ {
-		item := doc.Items[i]
+	return item.height(doc, columns)
+}
 
-		// Check item tax
-		if item.Tax == nil {
-			item.Tax = doc.DefaultTax
-		}
+// itemsKeepHeight return the height of the item line at index k and of what must stay on its page:
+// the next line of a section heading, the totals of the last line
+func (doc *Document) itemsKeepHeight(k int, columns []*itemColumnBox) float64 {
+	item := doc.Items[k]
+	height := doc.itemsRowHeight(item, columns)
 
-		// Append to pdf
-		item.appendColTo(doc.Options, doc)
+	if item.Type == ItemHeading && k < len(doc.Items)-1 {
+		height += itemsMargin + doc.itemsKeepHeight(k+1, columns)
+	} else if k == len(doc.Items)-1 && len(item.Children) == 0 {
+		height += itemsMargin + doc.totalsHeight()
+	}
 
-		if doc.pdf.GetY() > MaxPageHeight {
-			// Add page
-			doc.pdf.AddPage()
-			doc.drawsTableTitles()
-			doc.pdf.SetFont("Ubuntu", "", itemFontSize)
-		}
+	return height
+}
 
-		//doc.pdf.SetX(10)
-		doc.pdf.SetY(doc.pdf.GetY() + 6)
+// itemsPageBreak adds a page with the items table titles when a line of height does not fit above the bottom.
+// Lines higher than a whole page are drawn where they are.
+func (doc *Document) itemsPageBreak(columns []*itemColumnBox, height float64) {
+	if doc.pdf.GetY()+height <= doc.contentBottom() || doc.pdf.GetY() <= doc.itemsTop() {
+		return
 	}
+
+	// Add page
+	doc.addPage()
+	doc.drawsTableTitles(columns)
+	doc.pdf.SetY(doc.pdf.GetY() + itemFontSize + itemTitleMargin)
+	doc.setFont("", itemFontSize)
+}
+
+// notesRect return the rect of the notes, drawn left of the totals
+func (doc *Document) notesRect() *gopdf.Rect {
+	return &gopdf.Rect{W: doc.contentWidth() - doc.columnWidth(), H: doc.contentBottom() * 0.3}
 }
 
 func (doc *Document) appendNotes() {
@@ -254,233 +292,292 @@ func (doc *Document) appendNotes() {
 
 	currentY := doc.pdf.GetY()
 
-	doc.pdf.SetFont("Ubuntu", "", 9)
-	doc.pdf.SetX(BaseMargin)
+	doc.setFont("", notesFontSize)
+	doc.pdf.SetX(doc.Options.MarginLeft)
 	doc.pdf.SetMarginRight(100)
-	doc.pdf.SetY(currentY + 10)
+	doc.pdf.SetY(currentY + notesPaddingTop)
 
-	doc.pdf.MultiCell(
-		&gopdf.Rect{W: PageWidth - BaseMargin*2 - ColumnWidth, H: MaxPageHeight * 0.3},
-		doc.Notes,
-	)
+	doc.multiCell(doc.notesRect(), doc.Notes)
 
-	doc.pdf.SetMarginRight(BaseMargin)
+	doc.pdf.SetMarginRight(doc.Options.MarginRight)
 	doc.pdf.SetY(currentY)
 }
 
 func (doc *Document) appendTotal() {
-	ac := accounting.Accounting{
-		Symbol:    (doc.Options.CurrencySymbol),
-		Precision: doc.Options.CurrencyPrecision,
-		Thousand:  doc.Options.CurrencyThousand,
-		Decimal:   doc.Options.CurrencyDecimal,
-	}
+	doc.appendTotalRows(doc.totalRows())
+}
 
-	// Get total (without tax)
-	total, _ := decimal.NewFromString("0")
+// totalRow define a row of the totals bloc
+type totalRow struct {
+	title       string
+	description string
+	value       string
+	paidStamp   bool // Draws the paid stamp next to the row
+}
 
-	for _, item := range doc.Items {
-		total = total.Add(item.totalWithoutTaxAndWithDiscount())
-	}
+// totalRows return the rows of the totals bloc of invoices, quotations and delivery notes
+func (doc *Document) totalRows() []*totalRow {
+	ac := doc.accounting()
+
+	// Get total (without tax)
+	total := doc.totalWithoutTax()
 
 	// Apply document discount
-	totalWithDiscount := decimal.NewFromFloat(0)
-	if doc.Discount != nil {
-		discountType, discountNumber := doc.Discount.getDiscount()
+	totalWithDiscount := doc.totalWithDiscount()
 
-		if discountType == "amount" {
-			totalWithDiscount = total.Sub(discountNumber)
+	// Tax
+	taxGroups := doc.taxGroups()
+	totalTax := doc.totalTax()
+
+	// finalTotal
+	totalWithTax := doc.totalNet().Add(totalTax)
+
+	// TOTAL HT
+	rows := []*totalRow{{title: doc.Options.TextTotalNoTax, value: ac.FormatMoneyDecimal(total)}}
+
+	if doc.Discount != nil {
+		var descString bytes.Buffer
+		discountType, discountAmount := doc.Discount.getDiscount()
+		if discountType == "percent" {
+			descString.WriteString("-")
+			descString.WriteString(discountAmount.String())
+			descString.WriteString(" % / -")
+			descString.WriteString(ac.FormatMoneyDecimal(total.Sub(totalWithDiscount)))
 		} else {
-			// Percent
-			toSub := total.Mul(discountNumber.Div(decimal.NewFromFloat(100)))
-			totalWithDiscount = total.Sub(toSub)
+			descString.WriteString("-")
+			descString.WriteString(ac.FormatMoneyDecimal(discountAmount))
+
+			// No percent of an empty total, ex quotations of optional items only
+			if !total.IsZero() {
+				descString.WriteString(" / -")
+				descString.WriteString(
+					discountAmount.Mul(decimal.NewFromFloat(100)).Div(total).StringFixed(2),
+				)
+				descString.WriteString(" %")
+			}
 		}
+
+		// DISCOUNTED
+		rows = append(rows, &totalRow{title: doc.Options.TextTotalDiscounted, description: descString.String(), value: ac.FormatMoneyDecimal(totalWithDiscount)})
 	}
 
-	// Tax
-	totalTax := decimal.NewFromFloat(0)
-	if doc.Discount == nil {
-		for _, item := range doc.Items {
-			totalTax = totalTax.Add(item.taxWithDiscount())
+	// CHARGES and ALLOWANCES
+	for _, charge := range doc.Charges {
+		var description string
+		if chargeType, chargeNumber := charge.getCharge(); chargeType == "percent" {
+			description = fmt.Sprintf("%s %%", chargeNumber)
+		}
+
+		rows = append(rows, &totalRow{title: charge.Reason, description: description, value: ac.FormatMoneyDecimal(charge.amount(total))})
+	}
+
+	// TAX, split by name, category and rate when there is more than a single unnamed standard rate
+	if len(taxGroups) > 1 || (len(taxGroups) == 1 && (taxGroups[0].tax.category() != TaxCategoryStandard || len(taxGroups[0].tax.Name) > 0)) {
+		for _, group := range taxGroups {
+			rows = append(rows, &totalRow{title: group.title(doc.Options), value: ac.FormatMoneyDecimal(group.amount)})
 		}
 	} else {
-		discountType, discountAmount := doc.Discount.getDiscount()
-		discountPercent := discountAmount
-		if discountType == "amount" {
-			// Get percent from total discounted
-			discountPercent = discountAmount.Mul(decimal.NewFromFloat(100)).Div(totalWithDiscount)
+		rows = append(rows, &totalRow{title: doc.Options.TextTotalTax, value: ac.FormatMoneyDecimal(totalTax)})
+	}
+
+	// TOTAL TTC
+	rows = append(rows, &totalRow{title: doc.Options.TextTotalWithTax, value: ac.FormatMoneyDecimal(totalWithTax)})
+
+	// WITHHOLDING and PAYABLE
+	if withholdingGroups := doc.withholdingGroups(); len(withholdingGroups) > 0 {
+		for _, group := range withholdingGroups {
+			rows = append(rows, &totalRow{title: group.title(doc.Options), value: ac.FormatMoneyDecimal(group.amount.Neg())})
 		}
 
-		for _, item := range doc.Items {
-			if item.Tax != nil {
-				taxType, taxAmount := item.Tax.getTax()
-				if taxType == "amount" {
-					// If tax type is amount, juste add amount to tax
-					totalTax = totalTax.Add(taxAmount)
-				} else {
-					// Else, remove doc discount % from item total without tax and item discount
-					itemTotal := item.totalWithoutTaxAndWithDiscount()
-					toSub := discountPercent.Mul(itemTotal).Div(decimal.NewFromFloat(100))
-					itemTotalDiscounted := itemTotal.Sub(toSub)
-
-					// Then recompute tax on itemTotalDiscounted
-					itemTaxDiscounted := taxAmount.Mul(itemTotalDiscounted).Div(decimal.NewFromFloat(100))
-
-					totalTax = totalTax.Add(itemTaxDiscounted)
-				}
-			}
+		rows = append(rows, &totalRow{title: doc.Options.TextTotalPayable, value: ac.FormatMoneyDecimal(doc.totalPayable())})
+	}
+
+	// PAID and BALANCE DUE
+	if len(doc.Payments) > 0 {
+		for _, payment := range doc.Payments {
+			rows = append(rows, &totalRow{title: doc.Options.TextTotalPaid, description: payment.description(), value: ac.FormatMoneyDecimal(payment.amount().Neg())})
 		}
+
+		balanceDue := doc.balanceDue()
+		rows = append(rows, &totalRow{title: doc.Options.TextTotalBalanceDue, value: ac.FormatMoneyDecimal(balanceDue), paidStamp: !balanceDue.Round(int32(doc.Options.CurrencyPrecision)).IsPositive()})
 	}
 
-	// finalTotal
-	totalWithTax := total.Add(totalTax)
-	if doc.Discount != nil {
-		totalWithTax = totalWithDiscount.Add(totalTax)
+	// OPTIONAL ITEMS TOTAL, not part of the total
+	if doc.ShowOptionalTotal {
+		rows = append(rows, &totalRow{title: doc.Options.TextTotalOptional, value: fmt.Sprintf("(%s)", ac.FormatMoneyDecimal(doc.totalOptional()))})
 	}
 
-	doc.pdf.SetY(doc.pdf.GetY() + 10)
-	doc.pdf.SetFont("Ubuntu", "", LargeTextFontSize)
+	return rows
+}
+
+// appendTotalRows draws the totals bloc
+func (doc *Document) appendTotalRows(rows []*totalRow) {
+	doc.pdf.SetY(doc.pdf.GetY() + totalsPaddingTop)
+	doc.setFont("", LargeTextFontSize)
 	doc.pdf.SetTextColor(
 		doc.Options.BaseTextColor[0],
 		doc.Options.BaseTextColor[1],
 		doc.Options.BaseTextColor[2],
 	)
 
-	// Draw TOTAL HT title
-	doc.pdf.SetFillColor(doc.Options.DarkBgColor[0], doc.Options.DarkBgColor[1], doc.Options.DarkBgColor[2])
-	doc.pdf.SetStrokeColor(doc.Options.DarkBgColor[0], doc.Options.DarkBgColor[1], doc.Options.DarkBgColor[2])
-	doc.pdf.Rectangle(PageWidth-BaseMargin-ColumnWidth, doc.pdf.GetY(), PageWidth-BaseMargin-ColumnWidth/2, doc.pdf.GetY()+LargeTextFontSize+totalMargin*2, "F", 0, 0)
-	doc.pdf.SetX(PageWidth - BaseMargin - ColumnWidth)
-	doc.pdf.CellWithOption(
-		&gopdf.Rect{W: ColumnWidth/2 - totalMargin, H: LargeTextFontSize + totalMargin*2},
-		doc.Options.TextTotalNoTax,
-		gopdf.CellOption{Align: gopdf.Middle | gopdf.Right},
-	)
+	for _, row := range rows {
+		doc.appendTotalRow(row.title, row.description, row.value)
 
-	// Draw TOTAL HT amount
-	doc.pdf.SetFillColor(doc.Options.GreyBgColor[0], doc.Options.GreyBgColor[1], doc.Options.GreyBgColor[2])
-	doc.pdf.SetStrokeColor(doc.Options.GreyBgColor[0], doc.Options.GreyBgColor[1], doc.Options.GreyBgColor[2])
-	doc.pdf.Rectangle(PageWidth-BaseMargin-ColumnWidth/2, doc.pdf.GetY(), PageWidth-BaseMargin, doc.pdf.GetY()+LargeTextFontSize+totalMargin*2, "F", 0, 0)
-	doc.pdf.SetX(PageWidth - BaseMargin - ColumnWidth/2 + totalMargin)
-	doc.pdf.CellWithOption(
-		&gopdf.Rect{W: ColumnWidth/2 - totalMargin, H: LargeTextFontSize + totalMargin*2},
-		ac.FormatMoneyDecimal(total),
-		gopdf.CellOption{Align: gopdf.Middle},
-	)
+		if row.paidStamp {
+			doc.appendPaidStamp()
+		}
+	}
+}
 
-	if doc.Discount != nil {
-		baseY := doc.pdf.GetY() + LargeTextFontSize + totalMargin*2
+// totalsHeight return the height of the notes and totals bloc drawn after the items
+func (doc *Document) totalsHeight() float64 {
+	var rows []*totalRow
+	switch doc.Type {
+	case Reminder:
+		rows = doc.reminderTotalRows()
+	case Statement:
+		rows = doc.statementTotalRows()
+	default:
+		rows = doc.totalRows()
+	}
+
+	height := float64(totalsPaddingTop)
+	for _, row := range rows {
+		height += totalRowHeight(row.description)
+	}
+
+	// Payment term and tax exemption reasons are drawn under the totals
+	height += doc.paymentTermHeight() + doc.taxExemptionsHeight()
+
+	if len(doc.Notes) > 0 {
+		restore := doc.measureFont("", notesFontSize)
+		height = math.Max(height, notesPaddingTop+doc.multiCellHeight(doc.notesRect(), doc.Notes))
+		restore()
+	}
+
+	return height
+}
+
+// totalRowHeight return the height of a row of the totals bloc
+func totalRowHeight(description string) float64 {
+	height := LargeTextFontSize + totalMargin*2
+	if len(description) > 0 {
+		height += 5
+	}
+
+	return height
+}
 
-		// Draw DISCOUNTED title
-		doc.pdf.SetY(baseY)
-		doc.pdf.SetFillColor(doc.Options.DarkBgColor[0], doc.Options.DarkBgColor[1], doc.Options.DarkBgColor[2])
-		doc.pdf.SetStrokeColor(doc.Options.DarkBgColor[0], doc.Options.DarkBgColor[1], doc.Options.DarkBgColor[2])
-		doc.pdf.Rectangle(PageWidth-BaseMargin-ColumnWidth, doc.pdf.GetY(), PageWidth-BaseMargin-ColumnWidth/2, doc.pdf.GetY()+LargeTextFontSize+5+totalMargin*2, "F", 0, 0)
+// appendTotalRow draws a title and its value in the totals bloc, then moves y under the row
+func (doc *Document) appendTotalRow(title string, description string, value string) {
+	y := doc.pdf.GetY()
+	height := totalRowHeight(description)
 
+	// Draw title
+	doc.pdf.SetFillColor(doc.Options.DarkBgColor[0], doc.Options.DarkBgColor[1], doc.Options.DarkBgColor[2])
+	doc.pdf.SetStrokeColor(doc.Options.DarkBgColor[0], doc.Options.DarkBgColor[1], doc.Options.DarkBgColor[2])
+	doc.rectangle(doc.rightColumnX(), y, doc.contentRight()-doc.columnWidth()/2, y+height, "F")
+	doc.pdf.SetX(doc.rightColumnX())
+
+	if len(description) == 0 {
+		doc.cellWithOption(
+			&gopdf.Rect{W: doc.columnWidth()/2 - totalMargin, H: height},
+			title,
+			gopdf.CellOption{Align: gopdf.Middle | gopdf.Right},
+		)
+	} else {
 		// title
-		doc.pdf.SetX(PageWidth - BaseMargin - ColumnWidth)
-		doc.pdf.SetY(baseY + totalMargin)
-		doc.pdf.CellWithOption(
-			&gopdf.Rect{W: ColumnWidth/2 - totalMargin, H: LargeTextFontSize},
-			doc.Options.TextTotalDiscounted,
+		doc.pdf.SetY(y + totalMargin)
+		doc.cellWithOption(
+			&gopdf.Rect{W: doc.columnWidth()/2 - totalMargin, H: LargeTextFontSize},
+			title,
 			gopdf.CellOption{Align: gopdf.Right},
 		)
 
 		// description
-		doc.pdf.SetX(PageWidth - BaseMargin - ColumnWidth)
-		doc.pdf.SetY(baseY + 7.5 + totalMargin)
-		doc.pdf.SetFont("Ubuntu", "", BaseTextFontSize)
+		doc.pdf.SetX(doc.rightColumnX())
+		doc.pdf.SetY(y + 9.5 + totalMargin)
+		doc.setFont("", BaseTextFontSize)
 		doc.pdf.SetTextColor(doc.Options.GreyTextColor[0], doc.Options.GreyTextColor[1], doc.Options.GreyTextColor[2])
-
-		var descString bytes.Buffer
-		discountType, discountAmount := doc.Discount.getDiscount()
-		if discountType == "percent" {
-			descString.WriteString("-")
-			descString.WriteString(discountAmount.String())
-			descString.WriteString(" % / -")
-			descString.WriteString(ac.FormatMoneyDecimal(total.Sub(totalWithDiscount)))
-		} else {
-			descString.WriteString("-")
-			descString.WriteString(ac.FormatMoneyDecimal(discountAmount))
-			descString.WriteString(" / -")
-			descString.WriteString(
-				discountAmount.Mul(decimal.NewFromFloat(100)).Div(total).StringFixed(2),
-			)
-			descString.WriteString(" %")
-		}
-
-		doc.pdf.SetY(baseY + 9.5 + totalMargin)
-		doc.pdf.CellWithOption(
-			&gopdf.Rect{W: ColumnWidth/2 - totalMargin, H: BaseTextFontSize + 2},
-			descString.String(),
+		doc.cellWithOption(
+			&gopdf.Rect{W: doc.columnWidth()/2 - totalMargin, H: BaseTextFontSize + 2},
+			description,
 			gopdf.CellOption{Align: gopdf.Right},
 		)
 
-		doc.pdf.SetFont("Ubuntu", "", LargeTextFontSize)
+		doc.setFont("", LargeTextFontSize)
 		doc.pdf.SetTextColor(doc.Options.BaseTextColor[0], doc.Options.BaseTextColor[1], doc.Options.BaseTextColor[2])
-
-		// Draw DISCOUNT amount
-		doc.pdf.SetY(baseY)
-		doc.pdf.SetX(PageWidth - BaseMargin - ColumnWidth/2 + totalMargin)
-		doc.pdf.SetFillColor(doc.Options.GreyBgColor[0], doc.Options.GreyBgColor[1], doc.Options.GreyBgColor[2])
-		doc.pdf.SetStrokeColor(doc.Options.GreyBgColor[0], doc.Options.GreyBgColor[1], doc.Options.GreyBgColor[2])
-		doc.pdf.Rectangle(PageWidth-BaseMargin-ColumnWidth/2, doc.pdf.GetY()-totalMargin, PageWidth-BaseMargin, doc.pdf.GetY()+LargeTextFontSize+5+totalMargin*2, "F", 0, 0)
-		doc.pdf.CellWithOption(
-			&gopdf.Rect{W: ColumnWidth/2 - totalMargin, H: LargeTextFontSize + totalMargin*2},
-			ac.FormatMoneyDecimal(totalWithDiscount),
-			gopdf.CellOption{Align: gopdf.Middle},
-		)
-		doc.pdf.SetY(doc.pdf.GetY() + LargeTextFontSize + 5)
-	} else {
-		doc.pdf.SetY(doc.pdf.GetY() + LargeTextFontSize)
 	}
 
-	// Draw TAX title
-	doc.pdf.SetY(doc.pdf.GetY() + totalMargin*2)
-	doc.pdf.SetFillColor(doc.Options.DarkBgColor[0], doc.Options.DarkBgColor[1], doc.Options.DarkBgColor[2])
-	doc.pdf.SetStrokeColor(doc.Options.DarkBgColor[0], doc.Options.DarkBgColor[1], doc.Options.DarkBgColor[2])
-	doc.pdf.Rectangle(PageWidth-BaseMargin-ColumnWidth, doc.pdf.GetY(), PageWidth-BaseMargin-ColumnWidth/2, doc.pdf.GetY()+LargeTextFontSize+totalMargin*2, "F", 0, 0)
-	doc.pdf.SetX(PageWidth - BaseMargin - ColumnWidth)
-	doc.pdf.CellWithOption(
-		&gopdf.Rect{W: ColumnWidth/2 - totalMargin, H: LargeTextFontSize + totalMargin*2},
-		doc.Options.TextTotalTax,
-		gopdf.CellOption{Align: gopdf.Middle | gopdf.Right},
-	)
-
-	// Draw TAX amount
+	// Draw value
 	doc.pdf.SetFillColor(doc.Options.GreyBgColor[0], doc.Options.GreyBgColor[1], doc.Options.GreyBgColor[2])
 	doc.pdf.SetStrokeColor(doc.Options.GreyBgColor[0], doc.Options.GreyBgColor[1], doc.Options.GreyBgColor[2])
-	doc.pdf.Rectangle(PageWidth-BaseMargin-ColumnWidth/2, doc.pdf.GetY(), PageWidth-BaseMargin, doc.pdf.GetY()+LargeTextFontSize+totalMargin*2, "F", 0, 0)
-	doc.pdf.SetX(PageWidth - BaseMargin - ColumnWidth/2 + totalMargin)
-	doc.pdf.CellWithOption(
-		&gopdf.Rect{W: ColumnWidth/2 - totalMargin, H: LargeTextFontSize + totalMargin*2},
-		ac.FormatMoneyDecimal(totalTax),
+	doc.rectangle(doc.contentRight()-doc.columnWidth()/2, y, doc.contentRight(), y+height, "F")
+	doc.pdf.SetX(doc.contentRight() - doc.columnWidth()/2 + totalMargin)
+	doc.pdf.SetY(y)
+	doc.cellWithOption(
+		&gopdf.Rect{W: doc.columnWidth()/2 - totalMargin, H: height},
+		value,
 		gopdf.CellOption{Align: gopdf.Middle},
 	)
 
-	// Draw TOTAL TTC title
+	doc.pdf.SetY(y + height)
+}
+
+// appendTaxExemptions draws the reasons of taxes not charged (reverse charge, exemptions...)
+// taxExemptionReasons return the distinct exemption reasons of the tax groups, in order of first appearance
+func (doc *Document) taxExemptionReasons() []string {
+	var reasons []string
+	seen := map[string]bool{}
+	for _, group := range doc.taxGroups() {
+		if group.tax.category() == TaxCategoryStandard {
+			continue
+		}
+
+		reason := group.tax.exemptionReason(doc.Options)
+		if len(reason) == 0 || seen[reason] {
+			continue
+		}
+		seen[reason] = true
+		reasons = append(reasons, reason)
+	}
+
+	return reasons
+}
+
+// taxExemptionRect return the rect of a tax exemption reason, under the totals
+func (doc *Document) taxExemptionRect() *gopdf.Rect {
+	return &gopdf.Rect{W: doc.contentWidth(), H: BaseTextFontSize * 3}
+}
+
+func (doc *Document) appendTaxExemptions() {
+	reasons := doc.taxExemptionReasons()
+	if len(reasons) == 0 {
+		return
+	}
+
 	doc.pdf.SetY(doc.pdf.GetY() + totalMargin*2)
-	doc.pdf.SetX(PageWidth - BaseMargin - ColumnWidth)
-	doc.pdf.SetY(doc.pdf.GetY() + LargeTextFontSize)
-	doc.pdf.SetFillColor(doc.Options.DarkBgColor[0], doc.Options.DarkBgColor[1], doc.Options.DarkBgColor[2])
-	doc.pdf.SetStrokeColor(doc.Options.DarkBgColor[0], doc.Options.DarkBgColor[1], doc.Options.DarkBgColor[2])
-	doc.pdf.Rectangle(PageWidth-BaseMargin-ColumnWidth, doc.pdf.GetY(), PageWidth-BaseMargin-ColumnWidth/2, doc.pdf.GetY()+LargeTextFontSize+totalMargin*2, "F", 0, 0)
-	doc.pdf.CellWithOption(
-		&gopdf.Rect{W: ColumnWidth/2 - totalMargin, H: LargeTextFontSize + totalMargin*2},
-		doc.Options.TextTotalWithTax,
-		gopdf.CellOption{Align: gopdf.Middle | gopdf.Right},
-	)
+	doc.setFont("", BaseTextFontSize)
+	for _, reason := range reasons {
+		doc.pdf.SetX(doc.Options.MarginLeft)
+		doc.multiCell(doc.taxExemptionRect(), reason)
+	}
+}
 
-	// Draw TOTAL TTC amount
-	doc.pdf.SetFillColor(doc.Options.GreyBgColor[0], doc.Options.GreyBgColor[1], doc.Options.GreyBgColor[2])
-	doc.pdf.SetStrokeColor(doc.Options.GreyBgColor[0], doc.Options.GreyBgColor[1], doc.Options.GreyBgColor[2])
-	doc.pdf.Rectangle(PageWidth-BaseMargin-ColumnWidth/2, doc.pdf.GetY(), PageWidth-BaseMargin, doc.pdf.GetY()+LargeTextFontSize+totalMargin*2, "F", 0, 0)
-	doc.pdf.SetX(PageWidth - BaseMargin - ColumnWidth/2 + totalMargin)
-	doc.pdf.CellWithOption(
-		&gopdf.Rect{W: ColumnWidth/2 - totalMargin, H: LargeTextFontSize + totalMargin*2},
-		ac.FormatMoneyDecimal(totalWithTax),
-		gopdf.CellOption{Align: gopdf.Middle},
-	)
+// taxExemptionsHeight return the height of the tax exemption reasons drawn by appendTaxExemptions
+func (doc *Document) taxExemptionsHeight() float64 {
+	reasons := doc.taxExemptionReasons()
+	if len(reasons) == 0 {
+		return 0
+	}
+	defer doc.measureFont("", BaseTextFontSize)()
+
+	height := float64(totalMargin * 2)
+	for _, reason := range reasons {
+		height += doc.multiCellHeight(doc.taxExemptionRect(), reason)
+	}
+
+	return height
 }
 
 func (doc *Document) appendPaymentTerm() {
@@ -490,14 +587,54 @@ func (doc *Document) appendPaymentTerm() {
 			doc.Options.TextPaymentTermTitle,
 			doc.PaymentTerm,
 		)
-		doc.pdf.SetY(doc.pdf.GetY() + LargeTextFontSize + 5 + totalMargin*2)
+		doc.pdf.SetY(doc.pdf.GetY() + 5)
 
-		doc.pdf.SetX(PageWidth - BaseMargin - ColumnWidth)
-		doc.pdf.SetFont("Ubuntu", "B", LargeTextFontSize)
-		doc.pdf.CellWithOption(
-			&gopdf.Rect{W: ColumnWidth, H: LargeTextFontSize},
+		doc.pdf.SetX(doc.rightColumnX())
+		doc.setFont("B", LargeTextFontSize)
+		doc.cellWithOption(
+			&gopdf.Rect{W: doc.columnWidth(), H: LargeTextFontSize},
 			paymentTermString,
 			gopdf.CellOption{Align: gopdf.Right},
 		)
+		doc.pdf.SetY(doc.pdf.GetY() + LargeTextFontSize)
+	}
+
+	// Append cash discounts
+	if len(doc.CashDiscounts) > 0 {
+		ac := doc.accounting()
+
+		doc.setFont("", BaseTextFontSize)
+		doc.pdf.SetY(doc.pdf.GetY() + 3)
+		for _, cashDiscount := range doc.CashDiscounts {
+			cashDiscountString := fmt.Sprintf(
+				"%s %s %% %s %s: %s",
+				doc.Options.TextCashDiscountTitle,
+				cashDiscount.percent(),
+				doc.Options.TextCashDiscountUntil,
+				cashDiscount.deadline(doc.date()).Format(doc.Options.DateFormat),
+				ac.FormatMoneyDecimal(cashDiscount.discounted(doc.totalPayable())),
+			)
+
+			doc.pdf.SetX(doc.rightColumnX())
+			doc.cellWithOption(
+				&gopdf.Rect{W: doc.columnWidth(), H: BaseTextFontSize},
+				cashDiscountString,
+				gopdf.CellOption{Align: gopdf.Right},
+			)
+			doc.pdf.SetY(doc.pdf.GetY() + BaseTextFontSize + 2)
+		}
 	}
 }
+
+// paymentTermHeight return the height of the payment term and cash discounts drawn by appendPaymentTerm
+func (doc *Document) paymentTermHeight() float64 {
+	height := 0.0
+	if len(doc.PaymentTerm) > 0 {
+		height += 5 + LargeTextFontSize
+	}
+	if len(doc.CashDiscounts) > 0 {
+		height += 3 + float64(len(doc.CashDiscounts))*(BaseTextFontSize+2)
+	}
+
+	return height
+}
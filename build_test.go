@@ -0,0 +1,174 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/signintech/gopdf"
+)
+
+func TestMultiCellHeight(t *testing.T) {
+	doc := newTestDocument()
+	doc.pdf.AddPage()
+
+	text := strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 12)
+	for _, size := range []float64{SmallTextFontSize, BaseTextFontSize, 9} {
+		doc.setFont("", size)
+		rect := &gopdf.Rect{W: 200, H: 1000}
+
+		height := doc.multiCellHeight(rect, text)
+		y := doc.pdf.GetY()
+		doc.multiCell(rect, text)
+
+		if drawn := doc.pdf.GetY() - y; drawn-height > 0.001 || height-drawn > 0.001 {
+			t.Errorf("size %v: measured height %v, drawn %v", size, height, drawn)
+		}
+	}
+}
+
+func TestItemsPageBreak(t *testing.T) {
+	description := strings.Repeat("Long description of the delivered work. ", 5)
+
+	cases := []struct {
+		items int
+		pages int
+	}{
+		{1, 1},
+		{10, 1},
+		{20, 2},
+		{40, 3},
+		{100, 6},
+	}
+
+	for _, c := range cases {
+		doc := newTestDocument()
+		for k := 0; k < c.items; k++ {
+			doc.AppendItem(&Item{Name: "Work", UnitCost: "10", Quantity: "1", Description: description})
+		}
+
+		pdf, err := doc.Build()
+		if err != nil {
+			t.Fatalf("unexpected build error: %s", err)
+		}
+		if pages := pdf.GetNumberOfPages(); pages != c.pages {
+			t.Errorf("%d items: expected %d pages, got %d", c.items, c.pages, pages)
+		}
+	}
+
+	// A tall line starting above the bottom would overflow it, it is drawn on the next page
+	doc := newTestDocument()
+	doc.AppendItem(&Item{Name: "Work", UnitCost: "10", Quantity: "1", Tax: &Tax{Percent: "20"}})
+	doc.AppendItem(&Item{Name: "Work", UnitCost: "10", Quantity: "1", Tax: &Tax{Percent: "20"}, Description: strings.Repeat(description, 4)})
+	doc.AppendItem(&Item{Name: "Work", UnitCost: "10", Quantity: "1", Tax: &Tax{Percent: "20"}})
+
+	columns := doc.itemColumnBoxes()
+	firstHeight := doc.itemsRowHeight(doc.Items[0], columns)
+	tallHeight := doc.itemsRowHeight(doc.Items[1], columns)
+	y := doc.contentBottom() - firstHeight - itemsMargin - tallHeight/2
+	appendItemsAt(doc, y)
+
+	if pages := doc.pdf.GetNumberOfPages(); pages != 2 {
+		t.Fatalf("expected the tall line on a second page, got %d pages", pages)
+	}
+	lastHeight := doc.itemsRowHeight(doc.Items[2], columns)
+	if expected := doc.itemsTop() + tallHeight + lastHeight + 2*itemsMargin; doc.pdf.GetY() != expected {
+		t.Errorf("expected the tall line on top of the new page, items ending at %v, got %v", expected, doc.pdf.GetY())
+	}
+}
+
+// appendItemsAt draws the items table of doc with its first line at y, on a new page
+func appendItemsAt(doc *Document, y float64) {
+	doc.pdf.SetMargins(doc.Options.MarginLeft, doc.Options.MarginTop, doc.Options.MarginRight, doc.Options.MarginBottom)
+	doc.addPage()
+	doc.pdf.SetY(y - itemsPaddingTop - itemTitleMargin/2 - itemFontSize - itemTitleMargin)
+	doc.appendItems()
+}
+
+func TestItemsKeepHeight(t *testing.T) {
+	newDocument := func() *Document {
+		doc := newTestDocument()
+		doc.SetNotes("Thanks for your business")
+		doc.AppendHeading("Development")
+		doc.AppendItem(&Item{Name: "Backend", UnitCost: "200", Quantity: "2", Tax: &Tax{Percent: "20"}, Description: strings.Repeat("Long description. ", 30)})
+
+		return doc
+	}
+
+	doc := newDocument()
+	columns := doc.itemColumnBoxes()
+	headingHeight := doc.itemsRowHeight(doc.Items[0], columns)
+	rowHeight := doc.itemsRowHeight(doc.Items[1], columns)
+	if rowHeight <= itemFontSize*3 {
+		t.Errorf("expected long descriptions not to be cut, got row height %v", rowHeight)
+	}
+
+	// The row fits above the bottom but not with the totals: heading, row and totals move to the next page
+	y := doc.contentBottom() - headingHeight - rowHeight - 2*itemsMargin - doc.totalsHeight() + 1
+	appendItemsAt(doc, y)
+
+	if pages := doc.pdf.GetNumberOfPages(); pages != 2 {
+		t.Fatalf("expected the heading to move with its line, got %d pages", pages)
+	}
+	if expected := doc.itemsTop() + headingHeight + rowHeight + 2*itemsMargin; doc.pdf.GetY() != expected {
+		t.Errorf("expected the heading on top of the new page and the row under it ending at %v, got %v", expected, doc.pdf.GetY())
+	}
+	if bottom := doc.pdf.GetY() + doc.totalsHeight(); bottom > doc.contentBottom() {
+		t.Errorf("expected the totals to start under the last row, ending at %v", bottom)
+	}
+
+	// With room for the totals everything stays on the page
+	doc = newDocument()
+	y = doc.contentBottom() - headingHeight - rowHeight - 2*itemsMargin - doc.totalsHeight() - 1
+	appendItemsAt(doc, y)
+
+	if pages := doc.pdf.GetNumberOfPages(); pages != 1 {
+		t.Errorf("expected a single page, got %d", pages)
+	}
+	if expected := y + headingHeight + rowHeight + 2*itemsMargin; doc.pdf.GetY() != expected {
+		t.Errorf("expected the totals to start at %v, got %v", expected, doc.pdf.GetY())
+	}
+}
+
+func TestTotalsPageBreak(t *testing.T) {
+	newDocument := func(items int) *Document {
+		doc := newTestDocument()
+		doc.SetDate("01/03/2022")
+		doc.SetPaymentTerm("31/03/2022")
+		doc.AppendCashDiscount(&CashDiscount{Percent: "3", Days: 7})
+		doc.AppendCashDiscount(&CashDiscount{Percent: "2", Days: 14})
+		for k := 0; k < items; k++ {
+			doc.AppendItem(&Item{Name: "Service", UnitCost: "100", Quantity: "1", Tax: &Tax{Category: TaxCategoryReverseCharge}})
+		}
+
+		return doc
+	}
+
+	// Payment term, cash discounts and exemption reasons are kept above the page bottom
+	for items := 1; items <= 40; items++ {
+		doc := newDocument(items)
+		if _, err := doc.Build(); err != nil {
+			t.Fatalf("unexpected build error: %s", err)
+		}
+		if y := doc.pdf.GetY(); y > doc.contentBottom() {
+			t.Errorf("%d items: expected the totals to end above %v, got %v", items, doc.contentBottom(), y)
+		}
+	}
+
+	// From 24 items the totals bloc no longer fits under the items and moves to a second page
+	cases := []struct {
+		items int
+		pages int
+	}{
+		{23, 1},
+		{24, 2},
+	}
+	for _, c := range cases {
+		pdf, err := newDocument(c.items).Build()
+		if err != nil {
+			t.Fatalf("unexpected build error: %s", err)
+		}
+		if pages := pdf.GetNumberOfPages(); pages != c.pages {
+			t.Errorf("%d items: expected %d pages, got %d", c.items, c.pages, pages)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// bundleChildIndent define the indentation of bundle children names in the items table
+	bundleChildIndent = 10
+
+	// bundleChildMargin define the space between bundle children lines
+	bundleChildMargin = 3
+)
+
+// childrenUnitCost return the sum of the children totals, added to the unit cost of a priced bundle
+func (i *Item) childrenUnitCost() decimal.Decimal {
+	total := decimal.NewFromFloat(0)
+	if !i.ChildrenPriced {
+		return total
+	}
+
+	for _, child := range i.Children {
+		total = total.Add(child.subtotalWithDiscount())
+	}
+
+	return total
+}
+
+// childColumns return the items table columns of the bundle children lines.
+// Names are indented, prices are only shown when they add up into the bundle price.
+func (i *Item) childColumns(columns []*itemColumnBox) []*itemColumnBox {
+	var res []*itemColumnBox
+	for _, box := range columns {
+		switch box.column.Key {
+		case ItemColumnName:
+			res = append(res, &itemColumnBox{column: box.column, x: box.x + bundleChildIndent, w: box.w - bundleChildIndent})
+		case ItemColumnUnitCost, ItemColumnTotalWithoutTax:
+			if i.ChildrenPriced {
+				res = append(res, box)
+			}
+		case ItemColumnDiscount, ItemColumnTax, ItemColumnTotalWithTax:
+			continue
+		default:
+			res = append(res, box)
+		}
+	}
+
+	return res
+}
+
+// appendChildrenTo draws the bundle children indented under the item, moving whole lines to the next page.
+// The last child of the last item is kept with the totals.
+func (i *Item) appendChildrenTo(doc *Document, columns []*itemColumnBox, last bool) {
+	childColumns := i.childColumns(columns)
+	for k, child := range i.Children {
+		height := bundleChildMargin + child.height(doc, childColumns)
+		if last && k == len(i.Children)-1 {
+			height += itemsMargin + doc.totalsHeight()
+		}
+		doc.itemsPageBreak(columns, height)
+
+		doc.pdf.SetY(doc.pdf.GetY() + bundleChildMargin)
+		child.appendColTo(doc.Options, doc, childColumns)
+	}
+}
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBundleChildren(t *testing.T) {
+	children := func() []*Item {
+		return []*Item{
+			{Name: "Laptop", UnitCost: "900", Quantity: "1"},
+			{Name: "Dock", UnitCost: "150", Quantity: "1"},
+			{Name: "Screen", UnitCost: "200", Quantity: "2"},
+		}
+	}
+
+	doc := newTestDocument()
+	doc.SetDefaultTax(&Tax{Percent: "20"})
+	doc.AppendItem(&Item{Name: "Workstation kit", UnitCost: "1200", Quantity: "2", Children: children()})
+	doc.AppendItem(&Item{Name: "Assembled kit", UnitCost: "50", Quantity: "2", Children: children(), ChildrenPriced: true})
+
+	if unitCost := doc.Items[0].unitCost(); !unitCost.Equal(decimalFromString("1200")) {
+		t.Errorf("expected display only children to keep unit cost 1200, got %s", unitCost)
+	}
+	if unitCost := doc.Items[1].unitCost(); !unitCost.Equal(decimalFromString("1500")) {
+		t.Errorf("expected priced children to add up into unit cost 1500, got %s", unitCost)
+	}
+
+	if _, err := doc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+	if total := doc.totalWithoutTax(); !total.Equal(decimalFromString("5400")) {
+		t.Errorf("expected total 5400, got %s", total)
+	}
+	if tax := doc.totalTax(); !tax.Equal(decimalFromString("1080")) {
+		t.Errorf("expected tax 1080, got %s", tax)
+	}
+
+	if columns := doc.Items[0].childColumns(doc.itemColumnBoxes()); len(columns) != 2 {
+		t.Errorf("expected name and quantity columns for display only children, got %d", len(columns))
+	}
+
+	data, err := json.Marshal(doc.Items[1])
+	if err != nil {
+		t.Fatalf("unexpected marshal error: %s", err)
+	}
+	var item Item
+	if err := json.Unmarshal(data, &item); err != nil || len(item.Children) != 3 || !item.ChildrenPriced {
+		t.Errorf("expected children to round trip through JSON")
+	}
+
+	// Children discounts are part of the bundle price
+	doc.Items[1].Children[2].Discount = &Discount{Percent: "50"}
+	if unitCost := doc.Items[1].unitCost(); !unitCost.Equal(decimalFromString("1300")) {
+		t.Errorf("expected discounted children to add up into unit cost 1300, got %s", unitCost)
+	}
+
+	// Children are only drawn one level deep
+	doc.Items[1].Children[0].Children = children()
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for nested children")
+	}
+	doc.Items[1].Children[0].Children = nil
+
+	// Children are taxed with their bundle
+	for _, child := range []*Item{
+		{Name: "Tax", UnitCost: "1", Quantity: "1", Tax: &Tax{Percent: "10"}},
+		{Name: "Taxes", UnitCost: "1", Quantity: "1", Taxes: []*Tax{{Percent: "10"}}},
+		{Name: "Gross", UnitCost: "1", Quantity: "1", PriceIncludesTax: true},
+	} {
+		doc.Items[1].Children = append(children(), child)
+		if err := doc.Validate(); err == nil {
+			t.Errorf("%s: expected validation error for child tax", child.Name)
+		}
+	}
+	doc.Items[1].Children = children()
+	if err := doc.Validate(); err != nil {
+		t.Errorf("unexpected validation error: %s", err)
+	}
+
+	doc.Items[0].Children[0].Name = ""
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for child without name")
+	}
+}
@@ -0,0 +1,29 @@
+package generator
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// CashDiscount define an early payment discount (skonto), ex 2 % if paid within 10 days
+type CashDiscount struct {
+	Percent string `json:"percent,omitempty" validate:"required"` // Discount in percent ex 2
+	Days    int    `json:"days,omitempty" validate:"min=0"`       // Days from document date ex 10
+}
+
+func (c *CashDiscount) percent() decimal.Decimal {
+	percent, _ := decimal.NewFromString(c.Percent)
+	return percent
+}
+
+// deadline return the last day to pay with the discount
+func (c *CashDiscount) deadline(date time.Time) time.Time {
+	return date.AddDate(0, 0, c.Days)
+}
+
+// discounted return amount minus the discount
+func (c *CashDiscount) discounted(amount decimal.Decimal) decimal.Decimal {
+	toSub := amount.Mul(c.percent().Div(decimal.NewFromFloat(100)))
+	return amount.Sub(toSub)
+}
@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestCashDiscount(t *testing.T) {
+	doc := newTestDocument()
+	doc.SetDate("25/12/2021")
+	doc.SetPaymentTerm("25/01/2022")
+	doc.SetDefaultTax(&Tax{Percent: "19"})
+	doc.AppendItem(&Item{Name: "Service", UnitCost: "1000", Quantity: "1"})
+	doc.AppendCashDiscount(&CashDiscount{Percent: "3", Days: 7})
+	doc.AppendCashDiscount(&CashDiscount{Percent: "2", Days: 14})
+
+	if _, err := doc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+
+	expected := []struct {
+		deadline string
+		amount   string
+	}{
+		{"01/01/2022", "1154.3"},
+		{"08/01/2022", "1166.2"},
+	}
+	for i, e := range expected {
+		cashDiscount := doc.CashDiscounts[i]
+		if deadline := cashDiscount.deadline(doc.date()).Format(doc.Options.DateFormat); deadline != e.deadline {
+			t.Errorf("cash discount %d: expected deadline %s, got %s", i, e.deadline, deadline)
+		}
+		if amount := cashDiscount.discounted(doc.totalPayable()); !amount.Equal(decimalFromString(e.amount)) {
+			t.Errorf("cash discount %d: expected amount %s, got %s", i, e.amount, amount)
+		}
+	}
+
+	doc.SetDate("2021-12-25")
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for unparsable date")
+	}
+}
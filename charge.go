@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Charge define a document level charge (shipping, handling, surcharge...) or allowance,
+// as percent of items total or fixed amount
+type Charge struct {
+	Reason     string `json:"reason,omitempty" validate:"required"` // Reason ex Shipping
+	ReasonCode string `json:"reason_code,omitempty"`                // UNCL 7161 charge or UNCL 5189 allowance code ex FC
+	Percent    string `json:"percent,omitempty"`                    // Charge in percent of items total ex 2
+	Amount     string `json:"amount,omitempty"`                     // Charge in amount ex 12.50
+	Allowance  bool   `json:"allowance,omitempty"`                  // Deducted from the total instead of added
+	Tax        *Tax   `json:"tax,omitempty"`                        // Defaults to document default tax
+}
+
+func (c *Charge) getCharge() (string, decimal.Decimal) {
+	charge := "0"
+	chargeType := "percent"
+
+	if len(c.Percent) > 0 {
+		charge = c.Percent
+	}
+
+	if len(c.Amount) > 0 {
+		charge = c.Amount
+		chargeType = "amount"
+	}
+
+	decVal, _ := decimal.NewFromString(charge)
+
+	return chargeType, decVal
+}
+
+// amount return the signed charge amount for an items total, negative for allowances
+func (c *Charge) amount(itemsTotal decimal.Decimal) decimal.Decimal {
+	chargeType, chargeNumber := c.getCharge()
+
+	amount := chargeNumber
+	if chargeType == "percent" {
+		amount = itemsTotal.Mul(chargeNumber.Div(decimal.NewFromFloat(100)))
+	}
+
+	if c.Allowance {
+		return amount.Neg()
+	}
+
+	return amount
+}
+
+// taxes return the charge taxes, using the default tax when the charge has none
+func (c *Charge) taxes(defaultTax *Tax) []*Tax {
+	if c.Tax != nil {
+		return []*Tax{c.Tax}
+	}
+
+	if defaultTax != nil {
+		return []*Tax{defaultTax}
+	}
+
+	return nil
+}
@@ -10,20 +10,127 @@ const (
 	// DeliveryNote define the "delievry note" document type
 	DeliveryNote string = "DELIVERY_NOTE"
 
+	// Reminder define the "payment reminder" document type
+	Reminder string = "REMINDER"
+
+	// Statement define the "statement of account" document type
+	Statement string = "STATEMENT"
+
+	// EntryInvoice define an invoice in a statement ledger, debited to the customer
+	EntryInvoice string = "INVOICE"
+
+	// EntryCreditNote define a credit note in a statement ledger, credited to the customer
+	EntryCreditNote string = "CREDIT_NOTE"
+
+	// EntryPayment define a payment in a statement ledger, credited to the customer
+	EntryPayment string = "PAYMENT"
+
+	// StampDraft define the "draft" stamp preset
+	StampDraft string = "DRAFT"
+
+	// StampPaid define the "paid" stamp preset
+	StampPaid string = "PAID"
+
+	// StampCancelled define the "cancelled" stamp preset
+	StampCancelled string = "CANCELLED"
+
+	// StampCopy define the "copy" stamp preset
+	StampCopy string = "COPY"
+
+	// ItemHeading define an item line used as a section heading of the items table
+	ItemHeading string = "heading"
+
+	// ItemSubtotal define an item line used as the subtotal of the section lines above it
+	ItemSubtotal string = "subtotal"
+
+	// PricingGraduated define the "graduated" tiers pricing, each tier bills its own quantity range
+	PricingGraduated string = "graduated"
+
+	// PricingVolume define the "volume" tiers pricing, the whole quantity is billed at the reached tier
+	PricingVolume string = "volume"
+
+	// TaxCategoryStandard define the "standard rated" tax category
+	TaxCategoryStandard string = "S"
+
+	// TaxCategoryZero define the "zero rated" tax category
+	TaxCategoryZero string = "Z"
+
+	// TaxCategoryExempt define the "exempt from tax" tax category
+	TaxCategoryExempt string = "E"
+
+	// TaxCategoryReverseCharge define the "reverse charge" tax category
+	TaxCategoryReverseCharge string = "AE"
+
+	// TaxCategoryOutOfScope define the "not subject to tax" tax category
+	TaxCategoryOutOfScope string = "O"
+
+	// PageSizeA4 define the A4 page size
+	PageSizeA4 string = "A4"
+
+	// PageSizeLetter define the US letter page size
+	PageSizeLetter string = "LETTER"
+
+	// PageSizeLegal define the US legal page size
+	PageSizeLegal string = "LEGAL"
+
+	// OrientationPortrait define the portrait page orientation
+	OrientationPortrait string = "portrait"
+
+	// OrientationLandscape define the landscape page orientation
+	OrientationLandscape string = "landscape"
+
+	// ItemColumnName define the items table column of names, descriptions and price tiers
+	ItemColumnName string = "name"
+
+	// ItemColumnUnitCost define the items table column of unit prices
+	ItemColumnUnitCost string = "unit_cost"
+
+	// ItemColumnQuantity define the items table column of quantities
+	ItemColumnQuantity string = "quantity"
+
+	// ItemColumnTotalWithoutTax define the items table column of totals without tax
+	ItemColumnTotalWithoutTax string = "total_without_tax"
+
+	// ItemColumnDiscount define the items table column of discounts, hidden when no item has one
+	ItemColumnDiscount string = "discount"
+
+	// ItemColumnTax define the items table column of taxes, hidden when no item has one
+	ItemColumnTax string = "tax"
+
+	// ItemColumnTotalWithTax define the items table column of totals with tax
+	ItemColumnTotalWithTax string = "total_with_tax"
+
+	// ItemColumnAttribute define an items table column of an item attribute
+	ItemColumnAttribute string = "attribute"
+
+	// DirectionLTR define the left to right layout
+	DirectionLTR string = "ltr"
+
+	// DirectionRTL define the right to left layout, mirrored for arabic and hebrew
+	DirectionRTL string = "rtl"
+
 	// BaseMargin define base margin used in documents
+	//
+	// Deprecated: margins are set by Options.MarginLeft and Options.MarginRight
 	BaseMargin float64 = 30
 
 	// BaseMarginTop define base margin top used in documents
+	//
+	// Deprecated: margin top is set by Options.MarginTop
 	BaseMarginTop float64 = 40
 
 	// HeaderMarginTop define base header margin top used in documents
 	HeaderMarginTop float64 = 5
 
 	// MaxPageHeight define the maximum height for a single page
+	//
+	// Deprecated: the content bottom is derived from Options.PageSize, Options.Orientation and Options.MarginBottom
 	MaxPageHeight float64 = 900
 )
 
 // Cols offsets
+//
+// Deprecated: items columns offsets are derived from the content width
 const (
 	// ItemColUnitPriceOffset ...
 	ItemColUnitPriceOffset float64 = PageWidth * 0.4
@@ -59,13 +166,25 @@ var (
 )
 
 const (
-	ColumnWidth     = 250
-	PageWidth       = 592
-	itemFontSize    = 8
-	itemTitleMargin = 6
-	itemsPaddingTop = 40
-	metasFontSize   = 8
-	contactMargin   = 3
-	totalMargin     = 5
-	imageHeight     = 80
+	// ColumnWidth define the width of the company and customer columns
+	//
+	// Deprecated: columns width is derived from the content width
+	ColumnWidth = 250
+
+	// PageWidth define the width of an A4 portrait page
+	//
+	// Deprecated: page width is derived from Options.PageSize and Options.Orientation
+	PageWidth = 592
+
+	itemFontSize     = 8
+	itemTitleMargin  = 6
+	itemsPaddingTop  = 40
+	itemsMargin      = 6
+	metasFontSize    = 8
+	contactMargin    = 3
+	totalMargin      = 5
+	totalsPaddingTop = 10
+	notesFontSize    = 9
+	notesPaddingTop  = 10
+	imageHeight      = 80
 )
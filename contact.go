@@ -15,6 +15,8 @@ type Contact struct {
 	Name    string   `json:"name,omitempty" validate:"required,min=1,max=256"`
 	Logo    *[]byte  `json:"logo,omitempty"` // Logo byte array
 	Address *Address `json:"address,omitempty"`
+
+	BankAccounts []*BankAccount `json:"bank_accounts,omitempty" validate:"dive"`
 }
 
 func (c *Contact) appendContactTODoc(
@@ -38,12 +40,13 @@ func (c *Contact) appendContactTODoc(
 		if err != nil {
 			panic(err)
 		}
+		imgW := imageHeight * float64(b.Dx()) / float64(b.Dy())
 		if err := doc.pdf.ImageByHolderWithOptions(
 			imgH,
 			gopdf.ImageOptions{
-				X:    x,
+				X:    doc.drawX(x, imgW),
 				Y:    y,
-				Rect: &gopdf.Rect{W: imageHeight * float64(b.Dx()) / float64(b.Dy()), H: imageHeight},
+				Rect: &gopdf.Rect{W: imgW, H: imageHeight},
 				Transparency: &gopdf.Transparency{
 					Alpha:         0.0,
 					BlendModeType: "",
@@ -65,41 +68,64 @@ func (c *Contact) appendContactTODoc(
 	}
 
 	// Name rect
-	doc.pdf.Rectangle(x, doc.pdf.GetY(), x+ColumnWidth, doc.pdf.GetY()+LargeTextFontSize, "F", 0, 0)
+	doc.rectangle(x, doc.pdf.GetY(), x+doc.columnWidth(), doc.pdf.GetY()+LargeTextFontSize, "F")
 
 	// Reset x
 	doc.pdf.SetX(x + contactMargin)
 	// Set name
-	doc.pdf.SetFont("Ubuntu", "B", LargeTextFontSize)
-	doc.pdf.Cell(nil, c.Name)
-	doc.pdf.SetFont("Ubuntu", "", LargeTextFontSize)
+	doc.setFont("B", LargeTextFontSize)
+	doc.cell(nil, c.Name)
+	doc.setFont("", LargeTextFontSize)
 
 	if c.Address != nil {
-		// Address rect
-		lines := c.Address.lines()
-		var addrRectHeight float64 = LargeTextFontSize * float64(len(lines)+1)
+		// Address block
+		doc.pdf.SetY(doc.pdf.GetY() + LargeTextFontSize + 3)
+		appendContactBlock(x, c.Address.lines(doc.Options), doc)
+	}
 
-		offsetY := doc.pdf.GetY() + LargeTextFontSize + 3
-		doc.pdf.SetFillColor(doc.Options.GreyBgColor[0], doc.Options.GreyBgColor[1], doc.Options.GreyBgColor[2])
-		doc.pdf.SetStrokeColor(doc.Options.GreyBgColor[0], doc.Options.GreyBgColor[1], doc.Options.GreyBgColor[2])
-		doc.pdf.Rectangle(x, offsetY, x+ColumnWidth, doc.pdf.GetY()+addrRectHeight+contactMargin*2, "F", 0, 0)
-
-		doc.pdf.SetFont("Ubuntu", "", LargeTextFontSize)
-		doc.pdf.SetX(x + contactMargin)
-		doc.pdf.SetY(offsetY + contactMargin)
-		// Set address
-		for _, line := range lines {
-			doc.pdf.MultiCell(&gopdf.Rect{W: ColumnWidth, H: addrRectHeight}, line)
+	// Bank accounts blocks
+	for _, account := range c.bankAccounts() {
+		if !account.Show {
+			continue
 		}
+
+		doc.pdf.SetY(doc.pdf.GetY() + contactMargin)
+		appendContactBlock(x, account.lines(doc.Options), doc)
 	}
 
 	return doc.pdf.GetY()
 }
 
+// bankAccounts return the contact bank accounts, or a shown account from the deprecated address IBAN and bank name
+func (c *Contact) bankAccounts() []*BankAccount {
+	if len(c.BankAccounts) > 0 || c.Address == nil || len(c.Address.IBAN) == 0 && len(c.Address.BankName) == 0 {
+		return c.BankAccounts
+	}
+
+	return []*BankAccount{{IBAN: c.Address.IBAN, BankName: c.Address.BankName, Show: true}}
+}
+
 func (c *Contact) appendCompanyContactToDoc(doc *Document) float64 {
-	return c.appendContactTODoc(BaseMargin, BaseMarginTop, true, "L", doc)
+	return c.appendContactTODoc(doc.Options.MarginLeft, doc.Options.MarginTop, true, "L", doc)
 }
 
 func (c *Contact) appendCustomerContactToDoc(doc *Document) float64 {
-	return c.appendContactTODoc(PageWidth-BaseMargin-ColumnWidth, BaseMarginTop+45, true, "R", doc)
+	return c.appendContactTODoc(doc.rightColumnX(), doc.Options.MarginTop+45, true, "R", doc)
+}
+
+// appendContactBlock draws lines over a grey rect starting at current y
+func appendContactBlock(x float64, lines []string, doc *Document) {
+	var rectHeight float64 = LargeTextFontSize * float64(len(lines))
+
+	offsetY := doc.pdf.GetY()
+	doc.pdf.SetFillColor(doc.Options.GreyBgColor[0], doc.Options.GreyBgColor[1], doc.Options.GreyBgColor[2])
+	doc.pdf.SetStrokeColor(doc.Options.GreyBgColor[0], doc.Options.GreyBgColor[1], doc.Options.GreyBgColor[2])
+	doc.rectangle(x, offsetY, x+doc.columnWidth(), offsetY+rectHeight+contactMargin, "F")
+
+	doc.setFont("", LargeTextFontSize)
+	doc.pdf.SetX(x + contactMargin)
+	doc.pdf.SetY(offsetY + contactMargin)
+	for _, line := range lines {
+		doc.multiCell(&gopdf.Rect{W: doc.columnWidth(), H: rectHeight + LargeTextFontSize}, line)
+	}
 }
@@ -4,23 +4,37 @@ import "github.com/signintech/gopdf"
 
 // Document define base document
 type Document struct {
-	pdf *gopdf.GoPdf
+	pdf       *gopdf.GoPdf
+	fontStyle string            // Current font style
+	fontSize  float64           // Current font size
+	glyphs    map[glyphKey]bool // Fonts glyphs coverage
 
-	Options      *Options      `json:"options,omitempty"`
-	Header       *HeaderFooter `json:"header,omitempty"`
-	Footer       *HeaderFooter `json:"footer,omitempty"`
-	Type         string        `json:"type,omitempty" validate:"required,oneof=INVOICE DELIVERY_NOTE QUOTATION"`
-	Ref          string        `json:"ref,omitempty" validate:"required,min=1,max=32"`
-	Version      string        `json:"version,omitempty" validate:"max=32"`
-	ClientRef    string        `json:"client_ref,omitempty" validate:"max=64"`
-	Description  string        `json:"description,omitempty" validate:"max=1024"`
-	Notes        string        `json:"notes,omitempty"`
-	Company      *Contact      `json:"company,omitempty" validate:"required"`
-	Customer     *Contact      `json:"customer,omitempty" validate:"required"`
-	Items        []*Item       `json:"items,omitempty"`
-	Date         string        `json:"date,omitempty"`
-	ValidityDate string        `json:"validity_date,omitempty"`
-	PaymentTerm  string        `json:"payment_term,omitempty"`
-	DefaultTax   *Tax          `json:"default_tax,omitempty"`
-	Discount     *Discount     `json:"discount,omitempty"`
+	Options       *Options          `json:"options,omitempty"`
+	Header        *HeaderFooter     `json:"header,omitempty"`
+	Footer        *HeaderFooter     `json:"footer,omitempty"`
+	Type          string            `json:"type,omitempty" validate:"required,oneof=INVOICE DELIVERY_NOTE QUOTATION REMINDER STATEMENT"`
+	Ref           string            `json:"ref,omitempty" validate:"required,min=1,max=32"`
+	Version       string            `json:"version,omitempty" validate:"max=32"`
+	ClientRef     string            `json:"client_ref,omitempty" validate:"max=64"`
+	Description   string            `json:"description,omitempty" validate:"max=1024"`
+	Notes         string            `json:"notes,omitempty"`
+	Company       *Contact          `json:"company,omitempty" validate:"required"`
+	Customer      *Contact          `json:"customer,omitempty" validate:"required"`
+	Items         []*Item           `json:"items,omitempty" validate:"dive"`
+	Date          string            `json:"date,omitempty"`
+	ValidityDate  string            `json:"validity_date,omitempty"`
+	PaymentTerm   string            `json:"payment_term,omitempty"`
+	Payments      []*Payment        `json:"payments,omitempty" validate:"dive"` // Payments already received
+	CashDiscounts []*CashDiscount   `json:"cash_discounts,omitempty" validate:"dive"`
+	DefaultTax    *Tax              `json:"default_tax,omitempty"`
+	Discount      *Discount         `json:"discount,omitempty"`
+	Charges       []*Charge         `json:"charges,omitempty" validate:"dive"`
+	Withholding   *Withholding      `json:"withholding,omitempty"`
+	Dunning       *Dunning          `json:"dunning,omitempty"`   // Dunning informations of REMINDER documents
+	Statement     *AccountStatement `json:"statement,omitempty"` // Ledger of STATEMENT documents
+	Watermark     *Stamp            `json:"watermark,omitempty"` // Drawn across every page
+	Stamp         *Stamp            `json:"stamp,omitempty"`     // Drawn near the title
+
+	PricesIncludeTax  bool `json:"prices_include_tax,omitempty"`  // Items unit costs are gross prices, tax included
+	ShowOptionalTotal bool `json:"show_optional_total,omitempty"` // Draws the total of optional items under the totals
 }
@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/signintech/gopdf"
+	"github.com/signintech/gopdf/fontmaker/core"
+)
+
+// Font define a font family and its faces, each face given as TTF data or as a TTF file path.
+// Missing faces fall back to the regular one, bold italic falls back to bold.
+type Font struct {
+	Family         string `json:"family,omitempty" validate:"required"`
+	Regular        []byte `json:"-"`
+	RegularPath    string `json:"regular_path,omitempty"`
+	Bold           []byte `json:"-"`
+	BoldPath       string `json:"bold_path,omitempty"`
+	Italic         []byte `json:"-"`
+	ItalicPath     string `json:"italic_path,omitempty"`
+	BoldItalic     []byte `json:"-"`
+	BoldItalicPath string `json:"bold_italic_path,omitempty"`
+
+	lineHeightRatio float64 // Line height for a font size of 1
+}
+
+// defaultFont return the embedded Ubuntu Light font
+func defaultFont() *Font {
+	return &Font{Family: "Ubuntu", Regular: ubuntuTTF}
+}
+
+// fontFace return the face data, read from path when not given, or fallback when none
+func fontFace(data []byte, path string, fallback []byte) ([]byte, error) {
+	if len(data) > 0 {
+		return data, nil
+	}
+
+	if len(path) > 0 {
+		return os.ReadFile(path)
+	}
+
+	return fallback, nil
+}
+
+// register adds the font faces to the pdf
+func (f *Font) register(pdf *gopdf.GoPdf) error {
+	regular, err := fontFace(f.Regular, f.RegularPath, nil)
+	if err != nil {
+		return err
+	}
+	if len(regular) == 0 {
+		return fmt.Errorf("font %s: regular face is required", f.Family)
+	}
+
+	// Line height, from typographic ascender and descender like gopdf MultiCell
+	var parser core.TTFParser
+	if err := parser.ParseFontData(regular); err != nil {
+		return fmt.Errorf("font %s: %w", f.Family, err)
+	}
+	f.lineHeightRatio = float64(parser.TypoAscender()-parser.TypoDescender()) / float64(parser.UnitsPerEm())
+
+	bold, err := fontFace(f.Bold, f.BoldPath, regular)
+	if err != nil {
+		return err
+	}
+
+	italic, err := fontFace(f.Italic, f.ItalicPath, regular)
+	if err != nil {
+		return err
+	}
+
+	boldItalic, err := fontFace(f.BoldItalic, f.BoldItalicPath, bold)
+	if err != nil {
+		return err
+	}
+
+	faces := []struct {
+		style int
+		data  []byte
+	}{
+		{gopdf.Regular, regular},
+		{gopdf.Bold, bold},
+		{gopdf.Italic, italic},
+		{gopdf.Bold | gopdf.Italic, boldItalic},
+	}
+	for _, face := range faces {
+		if err := pdf.AddTTFFontDataWithOption(f.Family, face.data, gopdf.TtfOption{Style: face.style}); err != nil {
+			return fmt.Errorf("font %s: %w", f.Family, err)
+		}
+	}
+
+	return nil
+}
+
+// lineHeight return the height of a text line for a font size
+func (f *Font) lineHeight(size float64) float64 {
+	return f.lineHeightRatio * size
+}
+
+// setFont sets the document font family with a style ("", "B", "I" or "BI") and a size
+func (doc *Document) setFont(style string, size float64) {
+	doc.fontStyle = style
+	doc.fontSize = size
+	_ = doc.pdf.SetFont(doc.Options.Font.Family, style, size)
+}
+
+// measureFont sets a font to measure texts and return a function restoring the previous one
+func (doc *Document) measureFont(style string, size float64) func() {
+	previousStyle, previousSize := doc.fontStyle, doc.fontSize
+	doc.setFont(style, size)
+
+	return func() {
+		doc.setFont(previousStyle, previousSize)
+	}
+}
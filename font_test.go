@@ -0,0 +1,55 @@
+package generator
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDefaultFontFaces(t *testing.T) {
+	doc, err := New(Invoice, &Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, style := range []string{"", "B", "I", "BI"} {
+		if err := doc.pdf.SetFont("Ubuntu", style, 10); err != nil {
+			t.Errorf("style %q: %s", style, err)
+		}
+	}
+}
+
+func TestCustomFont(t *testing.T) {
+	// DejaVu Sans subsets checked in for tests
+	const (
+		regularPath = "testdata/DejaVuSans.ttf"
+		boldPath    = "testdata/DejaVuSans-Bold.ttf"
+	)
+
+	bold, err := os.ReadFile(boldPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	doc, err := New(Invoice, &Options{Font: &Font{Family: "DejaVu", RegularPath: regularPath, Bold: bold}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	doc.SetRef("ref")
+	doc.SetCompany(&Contact{Name: "Company", Address: &Address{Address: "Street 1"}})
+	doc.SetCustomer(&Contact{Name: "Customer", Address: &Address{Address: "Street 2"}})
+	doc.AppendItem(&Item{Name: "Service", UnitCost: "10", Quantity: "1"})
+
+	if _, err := doc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+	if err := doc.pdf.SetFont("Ubuntu", "", 10); err == nil {
+		t.Errorf("expected the default font not to be registered")
+	}
+
+	if _, err := New(Invoice, &Options{Font: &Font{Family: "Missing", RegularPath: "/missing.ttf"}}); err == nil {
+		t.Errorf("expected error for missing font file")
+	}
+	if _, err := New(Invoice, &Options{Font: &Font{Family: "Empty"}}); err == nil {
+		t.Errorf("expected error for font without regular face")
+	}
+}
@@ -20,9 +20,20 @@ func New(docType string, options *Options) (*Document, error) {
 		Type:    docType,
 	}
 
+	if options.Font == nil {
+		options.Font = defaultFont()
+	}
+
 	doc.pdf = &gopdf.GoPdf{}
-	doc.pdf.Start(gopdf.Config{PageSize: *gopdf.PageSizeA4})
-	doc.pdf.AddTTFFontData("Ubuntu", ubuntuTTF)
+	doc.pdf.Start(gopdf.Config{PageSize: options.pageSize()})
+	if err := options.Font.register(doc.pdf); err != nil {
+		return nil, err
+	}
+	for _, font := range options.FallbackFonts {
+		if err := font.register(doc.pdf); err != nil {
+			return nil, err
+		}
+	}
 
 	return doc, nil
 }
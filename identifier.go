@@ -0,0 +1,362 @@
+package generator
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// vatFormats define the EU VAT number format of each country (without the country prefix)
+var vatFormats = map[string]*regexp.Regexp{
+	"AT": regexp.MustCompile(`^U\d{8}$`),
+	"BE": regexp.MustCompile(`^[01]\d{9}$`),
+	"BG": regexp.MustCompile(`^\d{9,10}$`),
+	"CY": regexp.MustCompile(`^\d{8}[A-Z]$`),
+	"CZ": regexp.MustCompile(`^\d{8,10}$`),
+	"DE": regexp.MustCompile(`^\d{9}$`),
+	"DK": regexp.MustCompile(`^\d{8}$`),
+	"EE": regexp.MustCompile(`^\d{9}$`),
+	"EL": regexp.MustCompile(`^\d{9}$`),
+	"ES": regexp.MustCompile(`^[A-Z0-9]\d{7}[A-Z0-9]$`),
+	"FI": regexp.MustCompile(`^\d{8}$`),
+	"FR": regexp.MustCompile(`^[A-HJ-NP-Z0-9]{2}\d{9}$`),
+	"HR": regexp.MustCompile(`^\d{11}$`),
+	"HU": regexp.MustCompile(`^\d{8}$`),
+	"IE": regexp.MustCompile(`^(\d{7}[A-W][A-IW]?|\d[A-Z+*]\d{5}[A-W])$`),
+	"IT": regexp.MustCompile(`^\d{11}$`),
+	"LT": regexp.MustCompile(`^(\d{9}|\d{12})$`),
+	"LU": regexp.MustCompile(`^\d{8}$`),
+	"LV": regexp.MustCompile(`^\d{11}$`),
+	"MT": regexp.MustCompile(`^\d{8}$`),
+	"NL": regexp.MustCompile(`^\d{9}B\d{2}$`),
+	"PL": regexp.MustCompile(`^\d{10}$`),
+	"PT": regexp.MustCompile(`^\d{9}$`),
+	"RO": regexp.MustCompile(`^[1-9]\d{1,9}$`),
+	"SE": regexp.MustCompile(`^\d{10}01$`),
+	"SI": regexp.MustCompile(`^[1-9]\d{7}$`),
+	"SK": regexp.MustCompile(`^[1-9]\d{9}$`),
+	"XI": regexp.MustCompile(`^(\d{9}|\d{12}|GD\d{3}|HA\d{3})$`),
+}
+
+// vatChecksums define the check digits validation of countries having one
+var vatChecksums = map[string]func(string) bool{
+	"AT": checkATVAT,
+	"BE": checkBEVAT,
+	"CZ": checkCZVAT,
+	"DE": checkMod1110,
+	"DK": checkDKVAT,
+	"EL": checkELVAT,
+	"FI": checkFIVAT,
+	"FR": checkFRVAT,
+	"HR": checkMod1110,
+	"IT": checkLuhn,
+	"LU": checkLUVAT,
+	"NL": checkNLVAT,
+	"PL": checkPLVAT,
+	"PT": checkPTVAT,
+	"SE": func(number string) bool { return checkLuhn(number[:10]) },
+	"SI": checkSIVAT,
+	"SK": checkSKVAT,
+}
+
+// companyIDChecks define the national company ID validation of each supported country
+var companyIDChecks = map[string]func(string) bool{
+	"BE": func(number string) bool { return len(number) == 10 && checkBEVAT(number) },
+	"CZ": checkICO,
+	"DK": func(number string) bool { return len(number) == 8 && checkDKVAT(number) },
+	"FR": func(number string) bool { return (len(number) == 9 || len(number) == 14) && checkLuhn(number) },
+	"IT": func(number string) bool { return len(number) == 11 && checkLuhn(number) },
+	"NL": func(number string) bool { return len(number) == 8 },
+	"PL": checkREGON,
+	"SK": checkICO,
+}
+
+// normalizeIdentifier remove separators and uppercase an identifier
+func normalizeIdentifier(value string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", ".", "", "-", "", "/", "").Replace(value))
+}
+
+// vatCountry return the country prefix of a VAT number
+func vatCountry(vat string) string {
+	vat = normalizeIdentifier(vat)
+	if len(vat) < 2 {
+		return ""
+	}
+
+	return vat[:2]
+}
+
+// isValidVAT check an EU VAT number format and its check digits when the country has some.
+// Numbers without a known country prefix are accepted as is.
+func isValidVAT(vat string) bool {
+	vat = normalizeIdentifier(vat)
+	if len(vat) < 3 {
+		return false
+	}
+
+	country, number := vat[:2], vat[2:]
+	format, ok := vatFormats[country]
+	if !ok {
+		return true
+	}
+	if !format.MatchString(number) {
+		return false
+	}
+
+	if check, ok := vatChecksums[country]; ok {
+		return check(number)
+	}
+
+	return true
+}
+
+// isValidCompanyID check a national company ID (IČO, SIREN, KVK...) for the given country.
+// Countries without a known format are accepted as is.
+func isValidCompanyID(country string, id string) bool {
+	check, ok := companyIDChecks[strings.ToUpper(country)]
+	if !ok {
+		return true
+	}
+
+	id = normalizeIdentifier(id)
+	if !isDigits(id) {
+		return false
+	}
+
+	return check(id)
+}
+
+// isValidGTIN check a GTIN-8, GTIN-12 (UPC), GTIN-13 (EAN) or GTIN-14 check digit
+func isValidGTIN(gtin string) bool {
+	gtin = normalizeIdentifier(gtin)
+	if !isDigits(gtin) {
+		return false
+	}
+
+	switch len(gtin) {
+	case 8, 12, 13, 14:
+	default:
+		return false
+	}
+
+	// Weights 3 and 1 alternate from the rightmost digit, check digit included
+	sum := 0
+	d := digits(gtin)
+	for i := range d {
+		n := d[len(d)-1-i]
+		if i%2 == 1 {
+			n *= 3
+		}
+		sum += n
+	}
+
+	return sum%10 == 0
+}
+
+func isDigits(value string) bool {
+	if len(value) == 0 {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// digits return the digits of a numeric string
+func digits(number string) []int {
+	res := make([]int, len(number))
+	for i, r := range number {
+		res[i] = int(r - '0')
+	}
+
+	return res
+}
+
+// weightedSum return the sum of digits multiplied by weights
+func weightedSum(number string, weights ...int) int {
+	sum := 0
+	for i, d := range digits(number)[:len(weights)] {
+		sum += d * weights[i]
+	}
+
+	return sum
+}
+
+// checkLuhn validate a number with the Luhn algorithm
+func checkLuhn(number string) bool {
+	sum := 0
+	d := digits(number)
+	for i := range d {
+		n := d[len(d)-1-i]
+		if i%2 == 1 {
+			n *= 2
+			if n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+	}
+
+	return sum%10 == 0
+}
+
+// checkMod1110 validate a number with ISO 7064 MOD 11,10
+func checkMod1110(number string) bool {
+	d := digits(number)
+	product := 10
+	for _, n := range d[:len(d)-1] {
+		sum := (n + product) % 10
+		if sum == 0 {
+			sum = 10
+		}
+		product = (2 * sum) % 11
+	}
+
+	return (11-product)%10 == d[len(d)-1]
+}
+
+func checkATVAT(number string) bool {
+	d := digits(number[1:])
+	sum := 0
+	for i, n := range d[:7] {
+		if i%2 == 1 {
+			n = n*2/10 + n*2%10
+		}
+		sum += n
+	}
+
+	return (10-(sum+4)%10)%10 == d[7]
+}
+
+func checkBEVAT(number string) bool {
+	base, _ := strconv.Atoi(number[:8])
+	check, _ := strconv.Atoi(number[8:])
+
+	return 97-base%97 == check
+}
+
+func checkCZVAT(number string) bool {
+	// Only legal entities (8 digits) have check digits, individuals use their birth number
+	if len(number) != 8 {
+		return true
+	}
+
+	return checkICO(number)
+}
+
+func checkDKVAT(number string) bool {
+	return weightedSum(number, 2, 7, 6, 5, 4, 3, 2, 1)%11 == 0
+}
+
+func checkELVAT(number string) bool {
+	return weightedSum(number, 256, 128, 64, 32, 16, 8, 4, 2)%11%10 == digits(number)[8]
+}
+
+func checkFIVAT(number string) bool {
+	r := weightedSum(number, 7, 9, 10, 5, 8, 4, 2) % 11
+	if r == 1 {
+		return false
+	}
+
+	return (11-r)%11 == digits(number)[7]
+}
+
+func checkFRVAT(number string) bool {
+	// Old style keys contain letters and cannot be checked offline
+	if !isDigits(number[:2]) {
+		return true
+	}
+
+	key, _ := strconv.Atoi(number[:2])
+	siren, _ := strconv.Atoi(number[2:])
+
+	return (12+3*(siren%97))%97 == key
+}
+
+func checkLUVAT(number string) bool {
+	base, _ := strconv.Atoi(number[:6])
+	check, _ := strconv.Atoi(number[6:])
+
+	return base%89 == check
+}
+
+func checkNLVAT(number string) bool {
+	// Legal entities use the RSIN eleven test
+	if (weightedSum(number, 9, 8, 7, 6, 5, 4, 3, 2)-digits(number)[8])%11 == 0 {
+		return true
+	}
+
+	// Sole proprietors use a mod 97 check over the whole number
+	rest := 0
+	for _, r := range "NL" + number {
+		var value int
+		if r >= 'A' && r <= 'Z' {
+			value = int(r-'A') + 10
+			rest = (rest*100 + value) % 97
+		} else {
+			value = int(r - '0')
+			rest = (rest*10 + value) % 97
+		}
+	}
+
+	return rest == 1
+}
+
+func checkPLVAT(number string) bool {
+	return weightedSum(number, 6, 5, 7, 2, 3, 4, 5, 6, 7)%11 == digits(number)[9]
+}
+
+func checkPTVAT(number string) bool {
+	c := 11 - weightedSum(number, 9, 8, 7, 6, 5, 4, 3, 2)%11
+	if c >= 10 {
+		c = 0
+	}
+
+	return c == digits(number)[8]
+}
+
+func checkSIVAT(number string) bool {
+	c := 11 - weightedSum(number, 8, 7, 6, 5, 4, 3, 2)%11
+	if c == 11 {
+		return false
+	}
+
+	return c%10 == digits(number)[7]
+}
+
+func checkSKVAT(number string) bool {
+	value, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	return value%11 == 0 && strings.ContainsRune("234789", rune(number[2]))
+}
+
+// checkICO validate a czech or slovak company ID (IČO)
+func checkICO(number string) bool {
+	if len(number) != 8 {
+		return false
+	}
+
+	return (11-weightedSum(number, 8, 7, 6, 5, 4, 3, 2)%11)%10 == digits(number)[7]
+}
+
+// checkREGON validate a polish company ID (REGON) of 9 or 14 digits
+func checkREGON(number string) bool {
+	var sum int
+	switch len(number) {
+	case 9:
+		sum = weightedSum(number, 8, 9, 2, 3, 4, 5, 6, 7)
+	case 14:
+		sum = weightedSum(number, 2, 4, 8, 5, 0, 9, 7, 3, 6, 1, 2, 4, 8)
+	default:
+		return false
+	}
+
+	return sum%11%10 == digits(number)[len(number)-1]
+}
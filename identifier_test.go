@@ -0,0 +1,130 @@
+package generator
+
+import "testing"
+
+func TestIsValidVAT(t *testing.T) {
+	cases := map[string]bool{
+		"ATU13585627":       true,
+		"BE0411905847":      true,
+		"CZ25123891":        true,
+		"CZ7103192745":      true,
+		"DE136695976":       true,
+		"DK13585628":        true,
+		"EL094259216":       true,
+		"ES A12345674":      true,
+		"FI20774740":        true,
+		"FR40303265045":     true,
+		"FR 40 303 265 045": true,
+		"HR33392005961":     true,
+		"IT00743110157":     true,
+		"LU15027442":        true,
+		"NL004495445B01":    true,
+		"NL002455799B11":    true,
+		"PL5260250274":      true,
+		"PT501964843":       true,
+		"SE556188840401":    true,
+		"SI50223054":        true,
+		"SK2022749619":      true,
+		"ATU13585626":       false,
+		"BE0411905846":      false,
+		"CZ25123892":        false,
+		"DE136695977":       false,
+		"FR41303265045":     false,
+		"IT00743110158":     false,
+		"NL004495446B01":    false,
+		"PL5260250275":      false,
+		"SK2022749618":      false,
+		"SK2012749619":      false,
+		"SK20227496":        false,
+		"GB980780684":       true,
+		"CHE-116.281.710":   true,
+		"NO974760673MVA":    true,
+		"US123456789":       true,
+		"45432523543":       true,
+		"":                  false,
+	}
+
+	for vat, expected := range cases {
+		if got := isValidVAT(vat); got != expected {
+			t.Errorf("isValidVAT(%q) = %v, expected %v", vat, got, expected)
+		}
+	}
+}
+
+func TestIsValidCompanyID(t *testing.T) {
+	cases := []struct {
+		country  string
+		id       string
+		expected bool
+	}{
+		{"CZ", "25123891", true},
+		{"SK", "35763469", true},
+		{"SK", "35763468", false},
+		{"FR", "732829320", true},
+		{"FR", "732 829 320 00074", true},
+		{"FR", "732829321", false},
+		{"NL", "12345678", true},
+		{"NL", "1234567", false},
+		{"PL", "192598184", true},
+		{"PL", "192598185", false},
+		{"BE", "0411905847", true},
+		{"DE", "HRB 12345", true},
+		{"CZ", "ABCDEFGH", false},
+	}
+
+	for _, c := range cases {
+		if got := isValidCompanyID(c.country, c.id); got != c.expected {
+			t.Errorf("isValidCompanyID(%q, %q) = %v, expected %v", c.country, c.id, got, c.expected)
+		}
+	}
+}
+
+func TestIsValidGTIN(t *testing.T) {
+	cases := []struct {
+		gtin     string
+		expected bool
+	}{
+		{"4006381333931", true},
+		{"4006381333932", false},
+		{"036000291452", true},
+		{"73513537", true},
+		{"10012345678902", true},
+		{"400638133393", false},
+		{"40063813339A1", false},
+	}
+
+	for _, c := range cases {
+		if got := isValidGTIN(c.gtin); got != c.expected {
+			t.Errorf("isValidGTIN(%q) = %v, expected %v", c.gtin, got, c.expected)
+		}
+	}
+}
+
+func TestValidateAddressIdentifiers(t *testing.T) {
+	doc, _ := New(Invoice, &Options{})
+	doc.SetRef("ref")
+	doc.SetCompany(&Contact{
+		Name: "Company",
+		Address: &Address{
+			Address:    "Street 1",
+			BusinessID: "35763469",
+			VAT:        "SK2022749619",
+		},
+	})
+	doc.SetCustomer(&Contact{Name: "Customer"})
+
+	if err := doc.Validate(); err != nil {
+		t.Errorf("unexpected validation error: %s", err)
+	}
+
+	doc.Company.Address.BusinessID = "35763468"
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for invalid business ID")
+	}
+
+	doc.Company.Address.BusinessID = ""
+	doc.Company.Address.VAT = "SK2022749618"
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for invalid VAT number")
+	}
+}
@@ -2,6 +2,7 @@ package generator
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/leekchan/accounting"
 	"github.com/shopspring/decimal"
@@ -10,17 +11,81 @@ import (
 
 // Item represent a 'product' or a 'service'
 type Item struct {
-	Name        string    `json:"name,omitempty" validate:"required"`
-	Description string    `json:"description,omitempty"`
-	UnitCost    string    `json:"unit_cost,omitempty"`
-	Quantity    string    `json:"quantity,omitempty"`
-	Tax         *Tax      `json:"tax,omitempty"`
-	Discount    *Discount `json:"discount,omitempty"`
+	ID          string           `json:"id,omitempty"`                                               // Position identifier, referenced by alternatives
+	Type        string           `json:"type,omitempty" validate:"omitempty,oneof=heading subtotal"` // Section heading or subtotal line, not billed
+	Name        string           `json:"name,omitempty" validate:"required"`
+	Description string           `json:"description,omitempty"`
+	UnitCost    string           `json:"unit_cost,omitempty"`
+	Quantity    string           `json:"quantity,omitempty"`
+	Unit        string           `json:"unit,omitempty" validate:"omitempty,unit"`                      // UN/ECE Rec 20 unit code ex HUR, KGM, MTK, H87
+	Pricing     string           `json:"pricing,omitempty" validate:"omitempty,oneof=graduated volume"` // Tiers pricing model, defaults to graduated
+	Tiers       []*PriceTier     `json:"tiers,omitempty" validate:"dive"`                               // Price tiers, used instead of UnitCost
+	Tax         *Tax             `json:"tax,omitempty"`
+	Taxes       []*Tax           `json:"taxes,omitempty" validate:"dive"` // Ordered taxes, used instead of Tax
+	Discount    *Discount        `json:"discount,omitempty"`
+	Attributes  []*ItemAttribute `json:"attributes,omitempty" validate:"dive"` // Custom attributes in order ex SKU, project code
+
+	Children       []*Item `json:"children,omitempty" validate:"dive"` // Bundle components per unit, drawn indented under the item, without children or taxes of their own
+	ChildrenPriced bool    `json:"children_priced,omitempty"`          // Children totals add up into the unit cost, else they are display only
+
+	SellerID         string `json:"seller_id,omitempty"`          // Seller item identifier ex SKU
+	BuyerID          string `json:"buyer_id,omitempty"`           // Buyer item identifier ex customer part number
+	StandardID       string `json:"standard_id,omitempty"`        // Standard item identifier, a GTIN unless StandardIDScheme is set
+	StandardIDScheme string `json:"standard_id_scheme,omitempty"` // ISO 6523 scheme of StandardID, defaults to 0160 (GTIN)
+
+	Optional      bool   `json:"optional,omitempty"`       // Optional position, not counted in the totals
+	AlternativeTo string `json:"alternative_to,omitempty"` // ID of the item this position is an alternative to, not counted in the totals
+
+	PriceIncludesTax bool `json:"price_includes_tax,omitempty"` // Unit cost is a gross price, tax included
+
+	Withholding *Withholding `json:"withholding,omitempty"` // Overrides the document withholding
+}
+
+// ItemAttribute define a custom attribute of an item, shown in attribute columns
+type ItemAttribute struct {
+	Key   string `json:"key,omitempty" validate:"required"`
+	Value string `json:"value,omitempty"`
+}
+
+// attribute return the value of an item attribute, empty when not set
+func (i *Item) attribute(key string) string {
+	for _, attribute := range i.Attributes {
+		if attribute.Key == key {
+			return attribute.Value
+		}
+	}
+
+	return ""
+}
+
+// isGTIN return true when the standard identifier is a GTIN
+func (i *Item) isGTIN() bool {
+	return len(i.StandardIDScheme) == 0 || i.StandardIDScheme == "0160"
+}
+
+// identifiers return the seller, buyer and standard identifiers of the item on a single line
+func (i *Item) identifiers(options *Options) string {
+	var identifiers []string
+	if len(i.SellerID) > 0 {
+		identifiers = append(identifiers, fmt.Sprintf("%s: %s", options.TextItemSellerIDTitle, i.SellerID))
+	}
+	if len(i.BuyerID) > 0 {
+		identifiers = append(identifiers, fmt.Sprintf("%s: %s", options.TextItemBuyerIDTitle, i.BuyerID))
+	}
+	if len(i.StandardID) > 0 {
+		title := options.TextItemStandardIDTitle
+		if !i.isGTIN() {
+			title = i.StandardIDScheme
+		}
+		identifiers = append(identifiers, fmt.Sprintf("%s: %s", title, i.StandardID))
+	}
+
+	return strings.Join(identifiers, ", ")
 }
 
 func (i *Item) unitCost() decimal.Decimal {
 	unitCost, _ := decimal.NewFromString(i.UnitCost)
-	return unitCost
+	return unitCost.Add(i.childrenUnitCost())
 }
 
 func (i *Item) quantity() decimal.Decimal {
@@ -28,16 +93,68 @@ func (i *Item) quantity() decimal.Decimal {
 	return quantity
 }
 
-func (i *Item) totalWithoutTax() decimal.Decimal {
-	quantity, _ := decimal.NewFromString(i.Quantity)
-	price, _ := decimal.NewFromString(i.UnitCost)
-	total := price.Mul(quantity)
+// tierLines return the quantity billed in each price tier.
+// Graduated pricing bills each tier its own range, volume pricing bills the whole quantity in a single tier.
+func (i *Item) tierLines() []*priceTierLine {
+	var lines []*priceTierLine
+	quantity := i.quantity()
+	from := decimal.NewFromFloat(0)
+
+	for _, tier := range i.Tiers {
+		upTo, bounded := tier.upTo()
+
+		if i.Pricing == PricingVolume {
+			if !bounded || quantity.LessThanOrEqual(upTo) {
+				return []*priceTierLine{{
+					tier:     tier,
+					over:     from,
+					quantity: quantity,
+					total:    quantity.Mul(tier.unitCost()).Add(tier.flatFee()),
+				}}
+			}
+		} else {
+			tierQuantity := quantity.Sub(from)
+			if bounded && quantity.GreaterThan(upTo) {
+				tierQuantity = upTo.Sub(from)
+			}
+			if !tierQuantity.IsPositive() {
+				break
+			}
+
+			lines = append(lines, &priceTierLine{
+				tier:     tier,
+				over:     from,
+				quantity: tierQuantity,
+				total:    tierQuantity.Mul(tier.unitCost()).Add(tier.flatFee()),
+			})
+		}
 
-	return total
+		if !bounded {
+			break
+		}
+		from = upTo
+	}
+
+	return lines
+}
+
+// subtotal return unit cost × quantity, tax included when the item price includes tax
+func (i *Item) subtotal() decimal.Decimal {
+	if len(i.Tiers) > 0 {
+		total := decimal.NewFromFloat(0)
+		for _, line := range i.tierLines() {
+			total = total.Add(line.total)
+		}
+
+		return total
+	}
+
+	return i.unitCost().Mul(i.quantity())
 }
 
-func (i *Item) totalWithoutTaxAndWithDiscount() decimal.Decimal {
-	total := i.totalWithoutTax()
+// subtotalWithDiscount return the subtotal minus the item discount
+func (i *Item) subtotalWithDiscount() decimal.Decimal {
+	total := i.subtotal()
 
 	// Check discount
 	if i.Discount != nil {
@@ -55,190 +172,330 @@ func (i *Item) totalWithoutTaxAndWithDiscount() decimal.Decimal {
 	return total
 }
 
-func (i *Item) totalWithTaxAndDiscount() decimal.Decimal {
-	return i.totalWithoutTaxAndWithDiscount().Add(i.taxWithDiscount())
+// pricesIncludeTax return true when the item unit cost is a gross price, set on the item or on the document
+func (i *Item) pricesIncludeTax(doc *Document) bool {
+	return i.PriceIncludesTax || doc.PricesIncludeTax
 }
 
-func (i *Item) taxWithDiscount() decimal.Decimal {
-	result := decimal.NewFromFloat(0)
+func (i *Item) totalWithoutTax(doc *Document) decimal.Decimal {
+	if !i.pricesIncludeTax(doc) {
+		return i.subtotal()
+	}
 
-	if i.Tax == nil {
-		return result
+	net, _, _ := splitGross(i.taxes(), i.subtotal(), int32(doc.Options.CurrencyPrecision))
+	return net
+}
+
+func (i *Item) totalWithoutTaxAndWithDiscount(doc *Document) decimal.Decimal {
+	if !i.pricesIncludeTax(doc) {
+		return i.subtotalWithDiscount()
 	}
 
-	totalHT := i.totalWithoutTaxAndWithDiscount()
-	taxType, taxAmount := i.Tax.getTax()
+	net, _, _ := splitGross(i.taxes(), i.subtotalWithDiscount(), int32(doc.Options.CurrencyPrecision))
+	return net
+}
 
-	if taxType == "amount" {
-		result = taxAmount
-	} else {
-		divider := decimal.NewFromFloat(100)
-		result = totalHT.Mul(taxAmount.Div(divider))
+func (i *Item) totalWithTaxAndDiscount(doc *Document) decimal.Decimal {
+	return i.totalWithoutTaxAndWithDiscount(doc).Add(i.taxWithDiscount(doc))
+}
+
+// taxes return the item taxes in order of computation
+func (i *Item) taxes() []*Tax {
+	if len(i.Taxes) > 0 {
+		return i.Taxes
 	}
 
-	return result
+	if i.Tax != nil {
+		return []*Tax{i.Tax}
+	}
+
+	return nil
 }
 
-func (i *Item) appendColTo(options *Options, doc *Document) {
-	ac := accounting.Accounting{
-		Symbol:    (options.CurrencySymbol),
-		Precision: options.CurrencyPrecision,
-		Thousand:  options.CurrencyThousand,
-		Decimal:   options.CurrencyDecimal,
+// taxAmounts return the base and amount of each item tax, worked back out of the price when it includes tax
+func (i *Item) taxAmounts(doc *Document) ([]decimal.Decimal, []decimal.Decimal) {
+	if !i.pricesIncludeTax(doc) {
+		return computeTaxes(i.taxes(), i.subtotalWithDiscount())
 	}
 
+	_, bases, amounts := splitGross(i.taxes(), i.subtotalWithDiscount(), int32(doc.Options.CurrencyPrecision))
+	return bases, amounts
+}
+
+func (i *Item) taxWithDiscount(doc *Document) decimal.Decimal {
+	result := decimal.NewFromFloat(0)
+
+	_, amounts := i.taxAmounts(doc)
+	for _, amount := range amounts {
+		result = result.Add(amount)
+	}
+
+	return result
+}
+
+func (i *Item) appendColTo(options *Options, doc *Document, columns []*itemColumnBox) {
+	ac := doc.accounting()
+
 	// Get base Y (top of line)
 	baseY := doc.pdf.GetY()
 
-	// Name
-	doc.pdf.SetX(BaseMargin + itemTitleMargin)
-	doc.pdf.MultiCell(
-		&gopdf.Rect{
-			W: ItemColUnitPriceOffset - BaseMargin - itemTitleMargin*2,
-			H: itemFontSize * 3,
-		},
-		i.Name,
-	)
+	// Optional and alternative positions are muted
+	color := i.textColor(options)
+	doc.pdf.SetTextColor(color[0], color[1], color[2])
+
+	// Line height is measured first, from the wrapped names and attributes
+	colHeight := i.height(doc, columns)
+	for _, box := range columns {
+		switch box.column.Key {
+		case ItemColumnName:
+			i.appendNameTo(box, doc)
+		case ItemColumnAttribute:
+			doc.pdf.SetX(box.textX())
+			doc.itemMultiCell(box, i.attribute(box.column.Attribute))
+		}
+
+		doc.pdf.SetY(baseY)
+	}
+
+	for _, box := range columns {
+		doc.pdf.SetY(baseY)
+		doc.pdf.SetX(box.textX())
+
+		switch box.column.Key {
+		case ItemColumnUnitCost:
+			// Unit price, detailed by tiers when graduated
+			unitCost := ac.FormatMoneyDecimal(i.unitCost())
+			if tierLines := i.tierLines(); len(tierLines) > 1 {
+				unitCost = "--"
+			} else if len(tierLines) == 1 {
+				unitCost = ac.FormatMoneyDecimal(tierLines[0].tier.unitCost())
+			}
+			doc.itemCell(box, colHeight, i.formatPrice(unitCost))
+		case ItemColumnQuantity:
+			doc.itemCell(box, colHeight, i.quantityWithUnit(options))
+		case ItemColumnTotalWithoutTax:
+			doc.itemCell(box, colHeight, i.formatPrice(ac.FormatMoneyDecimal(i.totalWithoutTax(doc))))
+		case ItemColumnDiscount:
+			i.appendDiscountTo(box, baseY, colHeight, ac, doc)
+		case ItemColumnTax:
+			i.appendTaxesTo(box, baseY, colHeight, ac, doc)
+		case ItemColumnTotalWithTax:
+			doc.itemCell(box, colHeight, i.formatPrice(ac.FormatMoneyDecimal(i.totalWithTaxAndDiscount(doc))))
+		}
+	}
+
+	// Set Y for next line
+	doc.pdf.SetY(baseY + colHeight)
+	doc.pdf.SetTextColor(options.BaseTextColor[0], options.BaseTextColor[1], options.BaseTextColor[2])
+}
+
+// height return the height of the item line in the items table, measured as it is drawn by appendColTo
+func (i *Item) height(doc *Document, columns []*itemColumnBox) float64 {
+	colHeight := float64(itemFontSize)
+	for _, box := range columns {
+		var height float64
+		switch box.column.Key {
+		case ItemColumnName:
+			height = i.nameHeight(box, doc)
+		case ItemColumnAttribute:
+			height = doc.itemMultiCellHeight(box, i.attribute(box.column.Attribute))
+		case ItemColumnTax:
+			// Each tax is drawn on two lines
+			height = float64(len(i.taxes())) * BaseTextFontSize * 2
+		}
+
+		if height > colHeight {
+			colHeight = height
+		}
+	}
+
+	return colHeight
+}
+
+// itemCell draws a value in an items table column
+func (doc *Document) itemCell(box *itemColumnBox, height float64, value string) {
+	doc.cellWithOption(&gopdf.Rect{W: box.textW(), H: height}, value, gopdf.CellOption{Align: box.column.align() | gopdf.Top})
+}
+
+// itemMultiCell draws a value wrapped in an items table column, aligned like the column
+func (doc *Document) itemMultiCell(box *itemColumnBox, value string) {
+	if len(value) == 0 {
+		return
+	}
+
+	lineHeight := doc.Options.Font.lineHeight(doc.fontSize)
+	for _, line := range doc.wrapText(value, box.textW()) {
+		doc.pdf.SetX(box.textX())
+		doc.cellWithOption(&gopdf.Rect{W: box.textW(), H: lineHeight}, line, gopdf.CellOption{Align: box.column.align() | gopdf.Top})
+		doc.pdf.Br(lineHeight)
+	}
+}
+
+// itemMultiCellHeight return the height of a value drawn by itemMultiCell with the items font
+func (doc *Document) itemMultiCellHeight(box *itemColumnBox, value string) float64 {
+	if len(value) == 0 {
+		return 0
+	}
+
+	defer doc.measureFont(doc.fontStyle, itemFontSize)()
+	return float64(len(doc.wrapText(value, box.textW()))) * doc.Options.Font.lineHeight(itemFontSize)
+}
+
+// itemSubCell draws a value with its grey small description under it in an items table column
+func (doc *Document) itemSubCell(box *itemColumnBox, y float64, value string, description string, color []uint8) {
+	doc.pdf.SetX(box.textX())
+	doc.pdf.SetY(y)
+	doc.itemCell(box, BaseTextFontSize, value)
+
+	doc.pdf.SetX(box.textX())
+	doc.pdf.SetY(y + BaseTextFontSize)
+	doc.setFont("", SmallTextFontSize)
+	doc.pdf.SetTextColor(doc.Options.GreyTextColor[0], doc.Options.GreyTextColor[1], doc.Options.GreyTextColor[2])
+	doc.itemCell(box, BaseTextFontSize, description)
+
+	// reset font
+	doc.setFont("", BaseTextFontSize)
+	doc.pdf.SetTextColor(color[0], color[1], color[2])
+}
+
+// nameBlock define a text drawn in the name column, under the item name
+type nameBlock struct {
+	text string
+	size float64
+	grey bool
+}
+
+// nameBlocks return the texts of the name column in order: name, position label, identifiers, description and price tiers
+func (i *Item) nameBlocks(doc *Document) []*nameBlock {
+	ac := doc.accounting()
+
+	blocks := []*nameBlock{{text: i.Name, size: itemFontSize}}
+
+	// Optional or alternative position
+	if label := doc.positionLabel(i); len(label) > 0 {
+		blocks = append(blocks, &nameBlock{text: label, size: SmallTextFontSize})
+	}
+
+	// Identifiers
+	if identifiers := i.identifiers(doc.Options); len(identifiers) > 0 {
+		blocks = append(blocks, &nameBlock{text: identifiers, size: SmallTextFontSize, grey: true})
+	}
 
 	// Description
 	if len(i.Description) > 0 {
-		doc.pdf.SetX(BaseMargin + itemTitleMargin)
-
-		doc.pdf.SetFont("Ubuntu", "", SmallTextFontSize)
-		doc.pdf.SetTextColor(
-			doc.Options.GreyTextColor[0],
-			doc.Options.GreyTextColor[1],
-			doc.Options.GreyTextColor[2],
-		)
-
-		doc.pdf.MultiCell(
-			&gopdf.Rect{
-				W: ItemColUnitPriceOffset - BaseMargin - itemTitleMargin*2,
-				H: itemFontSize * 3,
-			},
-			i.Description,
-		)
+		blocks = append(blocks, &nameBlock{text: i.Description, size: SmallTextFontSize, grey: true})
+	}
 
-		// Reset font
-		doc.pdf.SetFont("Ubuntu", "", BaseTextFontSize)
-		doc.pdf.SetTextColor(
-			doc.Options.BaseTextColor[0],
-			doc.Options.BaseTextColor[1],
-			doc.Options.BaseTextColor[2],
-		)
+	// Price tiers, quantities shown with the item quantity precision
+	places := quantityPlaces(i.quantity())
+	for _, line := range i.tierLines() {
+		blocks = append(blocks, &nameBlock{text: line.description(ac, doc.Options, places), size: SmallTextFontSize, grey: true})
 	}
 
-	// Compute line height
-	colHeight := doc.pdf.GetY() - baseY
+	return blocks
+}
 
-	// Unit price
-	doc.pdf.SetY(baseY)
-	doc.pdf.SetX(ItemColUnitPriceOffset)
-	doc.pdf.Cell(&gopdf.Rect{W: ItemColQuantityOffset - ItemColUnitPriceOffset, H: colHeight}, ac.FormatMoneyDecimal(i.unitCost())) //, "0", 0, "", false, 0, "")
+// nameRect return the rect of the name column texts, as high as the page content so long descriptions are not cut
+func (doc *Document) nameRect(box *itemColumnBox) *gopdf.Rect {
+	return &gopdf.Rect{W: box.textW(), H: doc.contentBottom() - doc.Options.MarginTop}
+}
 
-	// Quantity
-	doc.pdf.SetX(ItemColQuantityOffset)
-	doc.pdf.Cell(&gopdf.Rect{W: ItemColTaxOffset - ItemColQuantityOffset, H: colHeight}, i.quantity().String()) //, "0", 0, "", false, 0, "")
+// appendNameTo draws the item name, its position label, identifiers, description and price tiers
+func (i *Item) appendNameTo(box *itemColumnBox, doc *Document) {
+	color := i.textColor(doc.Options)
+	for _, block := range i.nameBlocks(doc) {
+		doc.pdf.SetX(box.textX())
 
-	// Total HT
-	doc.pdf.SetX(ItemColTotalHTOffset)
-	doc.pdf.Cell(&gopdf.Rect{W: ItemColTaxOffset - ItemColTotalHTOffset, H: colHeight}, ac.FormatMoneyDecimal(i.totalWithoutTax())) //, "0", 0, "", false, 0, "")
+		doc.setFont("", block.size)
+		if block.grey {
+			doc.pdf.SetTextColor(doc.Options.GreyTextColor[0], doc.Options.GreyTextColor[1], doc.Options.GreyTextColor[2])
+		}
 
-	// Discount
-	doc.pdf.SetX(ItemColDiscountOffset)
+		doc.multiCell(doc.nameRect(box), block.text)
+
+		// Reset font
+		doc.setFont("", BaseTextFontSize)
+		doc.pdf.SetTextColor(color[0], color[1], color[2])
+	}
+}
+
+// nameHeight return the height of the name column texts, measured as they are drawn by appendNameTo
+func (i *Item) nameHeight(box *itemColumnBox, doc *Document) float64 {
+	height := 0.0
+	for _, block := range i.nameBlocks(doc) {
+		restore := doc.measureFont("", block.size)
+		height += doc.multiCellHeight(doc.nameRect(box), block.text)
+		restore()
+	}
+
+	return height
+}
+
+// appendDiscountTo draws the item discount and its equivalent amount or percent
+func (i *Item) appendDiscountTo(box *itemColumnBox, baseY float64, colHeight float64, ac accounting.Accounting, doc *Document) {
 	if i.Discount == nil {
-		doc.pdf.Cell(&gopdf.Rect{W: ItemColTotalTTCOffset - ItemColDiscountOffset, H: colHeight}, "--")
-	} else {
-		// If discount
-		discountType, discountAmount := i.Discount.getDiscount()
-		var discountTitle string
-		var discountDesc string
+		doc.itemCell(box, colHeight, "--")
+		return
+	}
 
-		if discountType == "percent" {
-			discountTitle = fmt.Sprintf("%s %s", discountAmount, "%")
-			// get amount from percent
-			dCost := i.totalWithoutTax()
-			dAmount := dCost.Mul(discountAmount.Div(decimal.NewFromFloat(100)))
-			discountDesc = fmt.Sprintf("-%s", ac.FormatMoneyDecimal(dAmount))
-		} else {
-			discountTitle = fmt.Sprintf("%s %s", discountAmount, "€")
-			dCost := i.totalWithoutTax()
-			dPerc := discountAmount.Mul(decimal.NewFromFloat(100))
-			dPerc = dPerc.Div(dCost)
-			// get percent from amount
-			discountDesc = fmt.Sprintf("-%s %%", dPerc.StringFixed(2))
-		}
+	discountType, discountAmount := i.Discount.getDiscount()
+	var discountTitle string
+	var discountDesc string
 
-		// discount title
-		// lastY := doc.pdf.GetY()
-		doc.pdf.Cell(&gopdf.Rect{W: ItemColTotalTTCOffset - ItemColDiscountOffset, H: colHeight / 2}, discountTitle)
-
-		// discount desc
-		doc.pdf.SetX(ItemColDiscountOffset)
-		doc.pdf.SetY(baseY + BaseTextFontSize)
-		doc.pdf.SetFont("Ubuntu", "", SmallTextFontSize)
-		doc.pdf.SetTextColor(doc.Options.GreyTextColor[0], doc.Options.GreyTextColor[1], doc.Options.GreyTextColor[2])
-
-		doc.pdf.Cell(&gopdf.Rect{W: ItemColTotalTTCOffset - ItemColDiscountOffset, H: colHeight / 2}, discountDesc)
-
-		// reset font and y
-		doc.pdf.SetFont("Ubuntu", "", BaseTextFontSize)
-		doc.pdf.SetTextColor(
-			doc.Options.BaseTextColor[0],
-			doc.Options.BaseTextColor[1],
-			doc.Options.BaseTextColor[2],
-		)
-		doc.pdf.SetY(baseY)
+	if discountType == "percent" {
+		discountTitle = fmt.Sprintf("%s %s", discountAmount, "%")
+		// get amount from percent
+		dCost := i.subtotal()
+		dAmount := dCost.Mul(discountAmount.Div(decimal.NewFromFloat(100)))
+		discountDesc = fmt.Sprintf("-%s", ac.FormatMoneyDecimal(dAmount))
+	} else {
+		discountTitle = fmt.Sprintf("%s %s", discountAmount, "€")
+		dCost := i.subtotal()
+		dPerc := discountAmount.Mul(decimal.NewFromFloat(100))
+		dPerc = dPerc.Div(dCost)
+		// get percent from amount
+		discountDesc = fmt.Sprintf("-%s %%", dPerc.StringFixed(2))
 	}
 
-	// Tax
-	doc.pdf.SetX(ItemColTaxOffset)
-	if i.Tax == nil {
+	doc.itemSubCell(box, baseY, discountTitle, discountDesc, i.textColor(doc.Options))
+}
+
+// appendTaxesTo draws each item tax on two lines, its rate and its amount
+func (i *Item) appendTaxesTo(box *itemColumnBox, baseY float64, colHeight float64, ac accounting.Accounting, doc *Document) {
+	taxes := i.taxes()
+	if len(taxes) == 0 {
 		// If no tax
-		doc.pdf.Cell(&gopdf.Rect{W: ItemColDiscountOffset - ItemColTaxOffset, H: colHeight}, "--")
-	} else {
-		// If tax
-		taxType, taxAmount := i.Tax.getTax()
+		doc.itemCell(box, colHeight, "--")
+		return
+	}
+
+	taxBases, taxAmounts := i.taxAmounts(doc)
+	for k, tax := range taxes {
+		taxType, taxAmount := tax.getTax()
 		var taxTitle string
 		var taxDesc string
 
 		if taxType == "percent" {
 			taxTitle = fmt.Sprintf("%s %s", taxAmount, ("%"))
 			// get amount from percent
-			dCost := i.totalWithoutTaxAndWithDiscount()
-			dAmount := dCost.Mul(taxAmount.Div(decimal.NewFromFloat(100)))
-			taxDesc = ac.FormatMoneyDecimal(dAmount)
+			taxDesc = ac.FormatMoneyDecimal(taxAmounts[k])
 		} else {
 			taxTitle = fmt.Sprintf("%s %s", taxAmount, ("€"))
-			dCost := i.totalWithoutTaxAndWithDiscount()
+			dCost := taxBases[k]
 			dPerc := taxAmount.Mul(decimal.NewFromFloat(100))
 			dPerc = dPerc.Div(dCost)
 			// get percent from amount
 			taxDesc = fmt.Sprintf("%s %%", dPerc.StringFixed(2))
 		}
 
-		// tax title
-		// lastY := doc.pdf.GetY()
-		doc.pdf.Cell(&gopdf.Rect{W: ItemColDiscountOffset - ItemColTaxOffset, H: colHeight / 2}, taxTitle)
-
-		// tax desc
-		doc.pdf.SetX(ItemColTaxOffset)
-		doc.pdf.SetY(baseY + BaseTextFontSize)
-		doc.pdf.SetFont("Ubuntu", "", SmallTextFontSize)
-		doc.pdf.SetTextColor(doc.Options.GreyTextColor[0], doc.Options.GreyTextColor[1], doc.Options.GreyTextColor[2])
+		if len(tax.Name) > 0 {
+			taxTitle = fmt.Sprintf("%s %s", tax.Name, taxTitle)
+		}
 
-		doc.pdf.Cell(&gopdf.Rect{W: ItemColDiscountOffset - ItemColTaxOffset, H: colHeight / 2}, taxDesc)
+		if category := tax.category(); category != TaxCategoryStandard {
+			taxTitle = fmt.Sprintf("%s %s", taxTitle, category)
+		}
 
-		// reset font and y
-		doc.pdf.SetFont("Ubuntu", "", BaseTextFontSize)
-		doc.pdf.SetTextColor(doc.Options.BaseTextColor[0], doc.Options.BaseTextColor[1], doc.Options.BaseTextColor[2])
-		doc.pdf.SetY(baseY)
+		doc.itemSubCell(box, baseY+float64(k)*BaseTextFontSize*2, taxTitle, taxDesc, i.textColor(doc.Options))
 	}
-
-	// TOTAL TTC
-	doc.pdf.SetX(ItemColTotalTTCOffset)
-	doc.pdf.Cell(&gopdf.Rect{W: 190 - ItemColTotalTTCOffset, H: colHeight}, ac.FormatMoneyDecimal(i.totalWithTaxAndDiscount()))
-
-	// Set Y for next line
-	doc.pdf.SetY(baseY + colHeight)
 }
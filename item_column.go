@@ -0,0 +1,142 @@
+package generator
+
+import (
+	"github.com/signintech/gopdf"
+)
+
+// ItemColumn define a column of the items table
+type ItemColumn struct {
+	Key       string  `json:"key,omitempty" validate:"required,oneof=name unit_cost quantity total_without_tax discount tax total_with_tax attribute"`
+	Attribute string  `json:"attribute,omitempty"`                                          // Item attribute key of attribute columns ex SKU
+	Title     string  `json:"title,omitempty"`                                              // Header label, defaults to the options text of the column
+	Width     float64 `json:"width,omitempty" validate:"min=0"`                             // Width relative to other columns, defaults to 1
+	Align     string  `json:"align,omitempty" validate:"omitempty,oneof=left center right"` // Defaults to left
+}
+
+// itemColumnBox define the position of a visible column of the items table
+type itemColumnBox struct {
+	column *ItemColumn
+	x      float64
+	w      float64
+}
+
+// defaultItemColumns return the columns of the items table when none is set in options
+func defaultItemColumns() []*ItemColumn {
+	return []*ItemColumn{
+		{Key: ItemColumnName, Width: 36.5},
+		{Key: ItemColumnUnitCost, Width: 11},
+		{Key: ItemColumnQuantity, Width: 8},
+		{Key: ItemColumnTotalWithoutTax, Width: 15.5},
+		{Key: ItemColumnDiscount, Width: 7},
+		{Key: ItemColumnTax, Width: 5.5},
+		{Key: ItemColumnTotalWithTax, Width: 16.5},
+	}
+}
+
+func (c *ItemColumn) width() float64 {
+	if c.Width > 0 {
+		return c.Width
+	}
+
+	return 1
+}
+
+func (c *ItemColumn) align() int {
+	switch c.Align {
+	case "right":
+		return gopdf.Right
+	case "center":
+		return gopdf.Center
+	}
+
+	return gopdf.Left
+}
+
+// title return the column title in the items table header
+func (c *ItemColumn) title(options *Options) string {
+	if len(c.Title) > 0 {
+		return c.Title
+	}
+
+	switch c.Key {
+	case ItemColumnName:
+		return options.TextItemsNameTitle
+	case ItemColumnUnitCost:
+		return options.TextItemsUnitCostTitle
+	case ItemColumnQuantity:
+		return options.TextItemsQuantityTitle
+	case ItemColumnTotalWithoutTax:
+		return options.TextItemsTotalHTTitle
+	case ItemColumnDiscount:
+		return options.TextItemsDiscountTitle
+	case ItemColumnTax:
+		return options.TextItemsTaxTitle
+	case ItemColumnTotalWithTax:
+		return options.TextItemsTotalTTCTitle
+	case ItemColumnAttribute:
+		return c.Attribute
+	}
+
+	return ""
+}
+
+// hidden return true when no item has a value in the column, discount and tax columns only
+func (c *ItemColumn) hidden(items []*Item) bool {
+	if c.Key != ItemColumnDiscount && c.Key != ItemColumnTax {
+		return false
+	}
+
+	for _, item := range items {
+		if c.Key == ItemColumnDiscount && item.Discount != nil || c.Key == ItemColumnTax && len(item.taxes()) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// itemColumnBoxes return the visible columns of the items table, sharing the content width by their relative width
+func (doc *Document) itemColumnBoxes() []*itemColumnBox {
+	columns := doc.Options.ItemColumns
+	if len(columns) == 0 {
+		columns = defaultItemColumns()
+	}
+
+	var visible []*ItemColumn
+	total := 0.0
+	for _, column := range columns {
+		if column.hidden(doc.Items) {
+			continue
+		}
+		visible = append(visible, column)
+		total += column.width()
+	}
+
+	// Only empty columns are set, keep them rather than an empty table
+	if len(visible) == 0 {
+		visible = columns
+		for _, column := range columns {
+			total += column.width()
+		}
+	}
+
+	boxes := make([]*itemColumnBox, len(visible))
+	x := doc.Options.MarginLeft
+	for k, column := range visible {
+		w := doc.contentWidth() * column.width() / total
+		boxes[k] = &itemColumnBox{column: column, x: x, w: w}
+		x += w
+	}
+
+	return boxes
+}
+
+// textX return the x of the column text
+func (b *itemColumnBox) textX() float64 {
+	return b.x + itemTitleMargin/2
+}
+
+// textW return the width of the column text
+func (b *itemColumnBox) textW() float64 {
+	return b.w - itemTitleMargin
+}
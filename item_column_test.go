@@ -0,0 +1,119 @@
+package generator
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestItemColumns(t *testing.T) {
+	doc := newTestDocument()
+	doc.AppendItem(&Item{Name: "Service", UnitCost: "10", Quantity: "1"})
+
+	keys := func(boxes []*itemColumnBox) []string {
+		var keys []string
+		for _, box := range boxes {
+			keys = append(keys, box.column.Key)
+		}
+		return keys
+	}
+
+	// Empty discount and tax columns are hidden
+	expected := []string{ItemColumnName, ItemColumnUnitCost, ItemColumnQuantity, ItemColumnTotalWithoutTax, ItemColumnTotalWithTax}
+	if got := keys(doc.itemColumnBoxes()); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected columns %v, got %v", expected, got)
+	}
+
+	doc.AppendItem(&Item{Name: "Product", UnitCost: "10", Quantity: "1", Tax: &Tax{Percent: "20"}, Discount: &Discount{Percent: "5"}})
+	if got := keys(doc.itemColumnBoxes()); len(got) != 7 {
+		t.Errorf("expected all columns, got %v", got)
+	}
+
+	// Visible columns share the content width
+	doc.Options.ItemColumns = []*ItemColumn{
+		{Key: ItemColumnQuantity, Width: 1, Align: "right"},
+		{Key: ItemColumnName, Width: 3},
+		{Key: ItemColumnDiscount, Width: 2},
+	}
+	boxes := doc.itemColumnBoxes()
+	if boxes[0].x != doc.Options.MarginLeft || boxes[0].w != doc.contentWidth()/6 || boxes[1].w != doc.contentWidth()/2 {
+		t.Errorf("unexpected columns layout %+v %+v", boxes[0], boxes[1])
+	}
+	if last := boxes[len(boxes)-1]; last.x+last.w != doc.contentRight() {
+		t.Errorf("columns must end at the right margin")
+	}
+
+	if _, err := doc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+
+	// Columns are kept when all of them would be hidden
+	empty := newTestDocument()
+	empty.Options.ItemColumns = []*ItemColumn{{Key: ItemColumnDiscount}, {Key: ItemColumnTax}}
+	empty.AppendItem(&Item{Name: "Service", UnitCost: "10", Quantity: "1"})
+	boxes = empty.itemColumnBoxes()
+	if len(boxes) != 2 || boxes[0].w != empty.contentWidth()/2 {
+		t.Errorf("expected the empty columns to be kept, got %v", keys(boxes))
+	}
+	if _, err := empty.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+
+	doc.Options.ItemColumns = append(doc.Options.ItemColumns, &ItemColumn{Key: "unknown"})
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for unknown column")
+	}
+}
+
+func TestItemAttributes(t *testing.T) {
+	doc := newTestDocument()
+	doc.Options.ItemColumns = []*ItemColumn{
+		{Key: ItemColumnName, Width: 3},
+		{Key: ItemColumnAttribute, Attribute: "SKU", Width: 1},
+		{Key: ItemColumnAttribute, Attribute: "project", Title: "Project code", Width: 1},
+		{Key: ItemColumnTotalWithTax, Width: 1, Align: "right"},
+	}
+	doc.AppendItem(&Item{
+		Name:     "Service",
+		UnitCost: "10",
+		Quantity: "1",
+		Attributes: []*ItemAttribute{
+			{Key: "SKU", Value: "SRV-001"},
+			{Key: "project", Value: "A long project code that must wrap on several lines"},
+		},
+	})
+
+	if value := doc.Items[0].attribute("SKU"); value != "SRV-001" {
+		t.Errorf("unexpected attribute value %s", value)
+	}
+	if value := doc.Items[0].attribute("missing"); value != "" {
+		t.Errorf("unexpected attribute value %s", value)
+	}
+	if title := doc.Options.ItemColumns[2].title(doc.Options); title != "Project code" {
+		t.Errorf("unexpected column title %s", title)
+	}
+
+	boxes := doc.itemColumnBoxes()
+	doc.setFont("", itemFontSize)
+	if lines := doc.wrapText(doc.Items[0].attribute("project"), boxes[2].textW()); len(lines) < 2 {
+		t.Errorf("expected attribute to wrap, got %v", lines)
+	}
+
+	if _, err := doc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+
+	data, err := json.Marshal(doc.Items[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.Contains(string(data), `"attributes":[{"key":"SKU","value":"SRV-001"}`) {
+		t.Errorf("expected attributes in JSON, got %s", data)
+	}
+
+	doc.Options.ItemColumns[1].Attribute = ""
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for attribute column without attribute")
+	}
+}
@@ -0,0 +1,136 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTieredPricing(t *testing.T) {
+	tiers := []*PriceTier{
+		{UpTo: "1000", UnitCost: "0.10"},
+		{UpTo: "10000", UnitCost: "0.08"},
+		{UnitCost: "0.05", FlatFee: "10"},
+	}
+
+	cases := []struct {
+		pricing  string
+		quantity string
+		lines    int
+		total    string
+	}{
+		{PricingGraduated, "500", 1, "50"},
+		{PricingGraduated, "1000", 1, "100"},
+		{PricingGraduated, "5000", 2, "420"},
+		{PricingGraduated, "12000", 3, "930"},
+		{PricingVolume, "500", 1, "50"},
+		{PricingVolume, "5000", 1, "400"},
+		{PricingVolume, "12000", 1, "610"},
+	}
+
+	for _, c := range cases {
+		item := &Item{Name: "Usage", Quantity: c.quantity, Pricing: c.pricing, Tiers: tiers}
+
+		if lines := item.tierLines(); len(lines) != c.lines {
+			t.Errorf("%s %s: expected %d tier lines, got %d", c.pricing, c.quantity, c.lines, len(lines))
+		}
+		if total := item.subtotal(); !total.Equal(decimalFromString(c.total)) {
+			t.Errorf("%s %s: expected total %s, got %s", c.pricing, c.quantity, c.total, total)
+		}
+	}
+
+	// Ranges show the tier bounds with the quantity precision
+	doc := newTestDocument()
+	ac := doc.accounting()
+	energy := &Item{Name: "Energy", Quantity: "1500.5", Unit: "KWH", Tiers: []*PriceTier{
+		{UpTo: "1000", UnitCost: "0.20"},
+		{UpTo: "1200.25", UnitCost: "0.15"},
+		{UnitCost: "0.10"},
+	}}
+	places := quantityPlaces(energy.quantity())
+	for k, expected := range []string{
+		"up to 1 000.0: 1 000.0 × ",
+		"over 1 000.0 up to 1 200.25: 200.25 × ",
+		"over 1 200.25: 300.25 × ",
+	} {
+		if description := energy.tierLines()[k].description(ac, doc.Options, places); !strings.HasPrefix(description, expected) {
+			t.Errorf("tier %d: expected description starting with %q, got %q", k, expected, description)
+		}
+	}
+
+	doc.SetDefaultTax(&Tax{Percent: "20"})
+	doc.AppendItem(&Item{Name: "Usage", Quantity: "12000", Tiers: tiers, Discount: &Discount{Percent: "10"}})
+	if _, err := doc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+	if tax := doc.totalTax(); !tax.Equal(decimalFromString("167.4")) {
+		t.Errorf("expected tax 167.4, got %s", tax)
+	}
+
+	doc.Items[0].Tiers = []*PriceTier{{UnitCost: "0.10"}, {UpTo: "1000", UnitCost: "0.08"}}
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for unordered tiers")
+	}
+
+	// Quantities beyond a bounded last tier would not be billed
+	for _, pricing := range []string{PricingGraduated, PricingVolume} {
+		doc.Items[0].Pricing = pricing
+		doc.Items[0].Tiers = []*PriceTier{{UpTo: "1000", UnitCost: "0.10"}, {UpTo: "10000", UnitCost: "0.08"}}
+		if err := doc.Validate(); err == nil {
+			t.Errorf("%s: expected validation error for a bounded last tier", pricing)
+		}
+
+		doc.Items[0].Tiers = append(doc.Items[0].Tiers, &PriceTier{UnitCost: "0.05"})
+		if err := doc.Validate(); err != nil {
+			t.Errorf("%s: unexpected validation error: %s", pricing, err)
+		}
+	}
+}
+
+func TestItemUnitsAndIdentifiers(t *testing.T) {
+	doc := newTestDocument()
+
+	cases := []struct {
+		unit     string
+		expected string
+	}{
+		{"", "2"},
+		{"HUR", "2 h"},
+		{"MTK", "2 m²"},
+		{"XBX", "2 XBX"},
+	}
+
+	for _, c := range cases {
+		item := &Item{Name: "Work", Quantity: "2", Unit: c.unit}
+		if got := item.quantityWithUnit(doc.Options); got != c.expected {
+			t.Errorf("unit %q: expected %q, got %q", c.unit, c.expected, got)
+		}
+	}
+
+	item := &Item{Name: "Screws", UnitCost: "3", Quantity: "2", Unit: "H87", SellerID: "SC-4", BuyerID: "P-77", StandardID: "4006381333931"}
+	if got := item.identifiers(doc.Options); got != "Item no.: SC-4, Your item no.: P-77, GTIN: 4006381333931" {
+		t.Errorf("unexpected identifiers %q", got)
+	}
+
+	doc.AppendItem(item)
+	if err := doc.Validate(); err != nil {
+		t.Errorf("unexpected validation error: %s", err)
+	}
+	if _, err := doc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+
+	item.StandardID = "4006381333932"
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for invalid GTIN")
+	}
+
+	item.StandardIDScheme = "0088"
+	if err := doc.Validate(); err != nil {
+		t.Errorf("unexpected validation error for non GTIN scheme: %s", err)
+	}
+
+	item.Unit = "hours"
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for invalid unit code")
+	}
+}
@@ -1,5 +1,12 @@
 package generator
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/leekchan/accounting"
+)
+
 func (d *Document) typeAsString() string {
 	if d.Type == Invoice {
 		return d.Options.TextTypeInvoice
@@ -9,5 +16,45 @@ func (d *Document) typeAsString() string {
 		return d.Options.TextTypeQuotation
 	}
 
+	if d.Type == Statement {
+		return d.Options.TextTypeStatement
+	}
+
+	if d.Type == Reminder {
+		if d.Dunning != nil && d.Dunning.Level > 0 {
+			return fmt.Sprintf("%s - %s %d", d.Options.TextTypeReminder, d.Options.TextDunningLevelTitle, d.Dunning.Level)
+		}
+
+		return d.Options.TextTypeReminder
+	}
+
 	return d.Options.TextTypeDeliveryNote
 }
+
+// date return the document date, today when not set
+func (d *Document) date() time.Time {
+	date, err := time.Parse(d.Options.DateFormat, d.Date)
+	if err != nil {
+		return time.Now()
+	}
+
+	return date
+}
+
+// daysBetween return the calendar days from a date to another, whatever the hours of these days
+func daysBetween(from time.Time, to time.Time) int {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// accounting return the money formatter of the document currency
+func (d *Document) accounting() accounting.Accounting {
+	return accounting.Accounting{
+		Symbol:    d.Options.CurrencySymbol,
+		Precision: d.Options.CurrencyPrecision,
+		Thousand:  d.Options.CurrencyThousand,
+		Decimal:   d.Options.CurrencyDecimal,
+	}
+}
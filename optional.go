@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// isBilled return true when the item is a line counted in the totals, not an optional or alternative position
+func (i *Item) isBilled() bool {
+	return i.isLine() && !i.Optional && len(i.AlternativeTo) == 0
+}
+
+// textColor return the text color of the item line, muted for optional and alternative positions
+func (i *Item) textColor(options *Options) []uint8 {
+	if i.isLine() && !i.isBilled() {
+		return options.MutedTextColor
+	}
+
+	return options.BaseTextColor
+}
+
+// formatPrice return a formatted item price, in parentheses for optional and alternative positions
+func (i *Item) formatPrice(price string) string {
+	if i.isLine() && !i.isBilled() {
+		return fmt.Sprintf("(%s)", price)
+	}
+
+	return price
+}
+
+// billedItems return the items counted in the totals
+func (doc *Document) billedItems() []*Item {
+	var items []*Item
+	for _, item := range doc.Items {
+		if item.isBilled() {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// itemByID return the document item with the given ID, nil when not found
+func (doc *Document) itemByID(id string) *Item {
+	for _, item := range doc.Items {
+		if len(item.ID) > 0 && item.ID == id {
+			return item
+		}
+	}
+
+	return nil
+}
+
+// positionLabel return the label of an optional or alternative position, empty for billed items
+func (doc *Document) positionLabel(item *Item) string {
+	if len(item.AlternativeTo) > 0 {
+		if alternative := doc.itemByID(item.AlternativeTo); alternative != nil {
+			return fmt.Sprintf("%s: %s", doc.Options.TextItemAlternative, alternative.Name)
+		}
+	}
+
+	if item.Optional {
+		return doc.Options.TextItemOptional
+	}
+
+	return ""
+}
+
+// totalOptional return the sum of optional items totals with tax, alternatives excluded
+func (doc *Document) totalOptional() decimal.Decimal {
+	total := decimal.NewFromFloat(0)
+	for _, item := range doc.Items {
+		if item.isLine() && item.Optional && len(item.AlternativeTo) == 0 {
+			total = total.Add(item.totalWithTaxAndDiscount(doc))
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestOptionalItems(t *testing.T) {
+	doc, _ := New(Quotation, &Options{})
+	doc.SetRef("ref")
+	doc.SetCompany(&Contact{Name: "Company", Address: &Address{Address: "Street 1"}})
+	doc.SetCustomer(&Contact{Name: "Customer", Address: &Address{Address: "Street 2"}})
+	doc.SetDefaultTax(&Tax{Percent: "20"})
+	doc.SetShowOptionalTotal(true)
+	doc.AppendItem(&Item{ID: "1", Name: "Hosting", UnitCost: "100", Quantity: "12"})
+	doc.AppendItem(&Item{Name: "Backups", UnitCost: "10", Quantity: "12", Optional: true})
+	doc.AppendItem(&Item{Name: "Premium hosting", UnitCost: "150", Quantity: "12", AlternativeTo: "1"})
+
+	if label := doc.positionLabel(doc.Items[1]); label != "Optional" {
+		t.Errorf("expected optional label, got %q", label)
+	}
+	if label := doc.positionLabel(doc.Items[2]); label != "Alternative to: Hosting" {
+		t.Errorf("expected alternative label, got %q", label)
+	}
+	if price := doc.Items[1].formatPrice("€ 10.00"); price != "(€ 10.00)" {
+		t.Errorf("expected optional price in parentheses, got %q", price)
+	}
+
+	if _, err := doc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+
+	if total := doc.totalWithoutTax(); !total.Equal(decimalFromString("1200")) {
+		t.Errorf("expected total 1200, got %s", total)
+	}
+	if tax := doc.totalTax(); !tax.Equal(decimalFromString("240")) {
+		t.Errorf("expected tax 240, got %s", tax)
+	}
+	if total := doc.totalOptional(); !total.Equal(decimalFromString("144")) {
+		t.Errorf("expected optional total 144, got %s", total)
+	}
+
+	doc.Items[2].AlternativeTo = "2"
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for unknown alternative item")
+	}
+}
+
+func TestOptionalItemsOnly(t *testing.T) {
+	doc := newTestDocument()
+	doc.SetType(Quotation)
+	doc.SetDefaultTax(&Tax{Percent: "20"})
+	doc.SetDiscount(&Discount{Amount: "10"})
+	doc.AppendItem(&Item{ID: "1", Name: "Support", UnitCost: "100", Quantity: "1", Optional: true})
+	doc.AppendItem(&Item{ID: "2", Name: "Premium support", UnitCost: "150", Quantity: "1", AlternativeTo: "1"})
+
+	if _, err := doc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+	if total := doc.totalWithoutTax(); !total.IsZero() {
+		t.Errorf("expected nothing billed, got %s", total)
+	}
+}
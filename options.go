@@ -7,15 +7,35 @@ type Options struct {
 	CurrencyDecimal   string `default:"." json:"currency_decimal,omitempty"`
 	CurrencyThousand  string `default:" " json:"currency_thousand,omitempty"`
 
+	DateFormat string `default:"02/01/2006" json:"date_format,omitempty"` // Go time layout of document dates
+
+	Font          *Font   `json:"font,omitempty"`           // Defaults to the embedded Ubuntu Light
+	FallbackFonts []*Font `json:"fallback_fonts,omitempty"` // Fonts used in order for glyphs missing from the font
+
+	Direction    string  `default:"ltr" json:"direction,omitempty" validate:"oneof=ltr rtl"`
+	PageSize     string  `default:"A4" json:"page_size,omitempty" validate:"oneof=A4 LETTER LEGAL"`
+	Orientation  string  `default:"portrait" json:"orientation,omitempty" validate:"oneof=portrait landscape"`
+	MarginLeft   float64 `default:"30" json:"margin_left,omitempty"`
+	MarginRight  float64 `default:"30" json:"margin_right,omitempty"`
+	MarginTop    float64 `default:"40" json:"margin_top,omitempty"`
+	MarginBottom float64 `default:"30" json:"margin_bottom,omitempty"`
+
 	TextTypeInvoice      string `default:"INVOICE" json:"text_type_invoice,omitempty"`
 	TextTypeQuotation    string `default:"QUOTATION" json:"text_type_quotation,omitempty"`
 	TextTypeDeliveryNote string `default:"DELIVERY NOTE" json:"text_type_delivery_note,omitempty"`
+	TextTypeReminder     string `default:"PAYMENT REMINDER" json:"text_type_reminder,omitempty"`
+	TextTypeStatement    string `default:"STATEMENT OF ACCOUNT" json:"text_type_statement,omitempty"`
 
 	TextRefTitle         string `default:"Ref." json:"text_ref_title,omitempty"`
 	TextVersionTitle     string `default:"Version" json:"text_version_title,omitempty"`
 	TextDateTitle        string `default:"Date" json:"text_date_title,omitempty"`
 	TextPaymentTermTitle string `default:"Payment term" json:"text_payment_term_title,omitempty"`
 
+	TextCashDiscountTitle string `default:"Cash discount" json:"text_cash_discount_title,omitempty"`
+	TextCashDiscountUntil string `default:"if paid by" json:"text_cash_discount_until,omitempty"`
+
+	ItemColumns []*ItemColumn `json:"item_columns,omitempty" validate:"dive"` // Items table columns in order, defaults to all
+
 	TextItemsNameTitle     string `default:"Name" json:"text_items_name_title,omitempty"`
 	TextItemsUnitCostTitle string `default:"Unit price" json:"text_items_unit_cost_title,omitempty"`
 	TextItemsQuantityTitle string `default:"Qty" json:"text_items_quantity_title,omitempty"`
@@ -24,14 +44,73 @@ type Options struct {
 	TextItemsDiscountTitle string `default:"Discount" json:"text_items_discount_title,omitempty"`
 	TextItemsTotalTTCTitle string `default:"Total" json:"text_items_total_ttc_title,omitempty"`
 
+	TextItemSellerIDTitle   string            `default:"Item no." json:"text_item_seller_id_title,omitempty"`
+	TextItemBuyerIDTitle    string            `default:"Your item no." json:"text_item_buyer_id_title,omitempty"`
+	TextItemStandardIDTitle string            `default:"GTIN" json:"text_item_standard_id_title,omitempty"`
+	TextItemOptional        string            `default:"Optional" json:"text_item_optional,omitempty"`
+	TextItemAlternative     string            `default:"Alternative to" json:"text_item_alternative,omitempty"`
+	TextItemTierOver        string            `default:"over" json:"text_item_tier_over,omitempty"`
+	TextItemTierUpTo        string            `default:"up to" json:"text_item_tier_up_to,omitempty"`
+	TextUnits               map[string]string `default:"{\"C62\":\"pcs\",\"H87\":\"pcs\",\"HUR\":\"h\",\"MIN\":\"min\",\"DAY\":\"days\",\"MON\":\"months\",\"KGM\":\"kg\",\"GRM\":\"g\",\"TNE\":\"t\",\"MTR\":\"m\",\"KMT\":\"km\",\"MTK\":\"m²\",\"MTQ\":\"m³\",\"LTR\":\"l\",\"KWH\":\"kWh\",\"SET\":\"sets\"}" json:"text_units,omitempty"` // Unit label by UN/ECE Rec 20 unit code
+
 	TextTotalTotal      string `default:"TOTAL" json:"text_total_total,omitempty"`
 	TextTotalDiscounted string `default:"TOTAL DISCOUNTED" json:"text_total_discounted,omitempty"`
 	TextTotalTax        string `default:"TAX" json:"text_total_tax,omitempty"`
 	TextTotalWithTax    string `default:"TOTAL WITH TAX" json:"text_total_with_tax,omitempty"`
-	TextTotalNoTax      string `default:"TOTAL WITHOUTH TAX" json:"text_total_with_tax,omitempty"`
+	TextTotalNoTax      string `default:"TOTAL WITHOUTH TAX" json:"text_total_no_tax,omitempty"`
+
+	TextTotalWithholding string `default:"WITHHOLDING" json:"text_total_withholding,omitempty"`
+	TextTotalPayable     string `default:"TOTAL PAYABLE" json:"text_total_payable,omitempty"`
+	TextTotalPaid        string `default:"PAID" json:"text_total_paid,omitempty"`
+	TextTotalBalanceDue  string `default:"BALANCE DUE" json:"text_total_balance_due,omitempty"`
+	TextStampPaid        string `default:"PAID" json:"text_stamp_paid,omitempty"`
+	TextTotalOptional    string `default:"OPTIONAL ITEMS TOTAL" json:"text_total_optional,omitempty"`
+
+	TextTaxCategories map[string]string `default:"{\"Z\":\"ZERO RATED\",\"E\":\"TAX EXEMPT\",\"AE\":\"REVERSE CHARGE\",\"O\":\"NOT SUBJECT TO TAX\"}" json:"text_tax_categories,omitempty"` // Totals tax title by tax category
+	TextReverseCharge string            `default:"Reverse charge – Article 196 Directive 2006/112/EC" json:"text_reverse_charge,omitempty"`
+
+	TextVATTitle         string            `default:"VAT ID" json:"text_vat_title,omitempty"`
+	TextTaxIDTitle       string            `default:"Tax ID" json:"text_tax_id_title,omitempty"`
+	TextBusinessIDTitle  string            `default:"Business ID" json:"text_business_id_title,omitempty"`
+	TextBusinessIDTitles map[string]string `default:"{\"BE\":\"KBO/BCE\",\"CZ\":\"IČO\",\"DK\":\"CVR\",\"FR\":\"SIREN\",\"IT\":\"Codice fiscale\",\"NL\":\"KVK\",\"PL\":\"REGON\",\"SK\":\"IČO\"}" json:"text_business_id_titles,omitempty"` // Business ID title by country code
+
+	TextDunningLevelTitle        string `default:"LEVEL" json:"text_dunning_level_title,omitempty"`
+	TextReminderInvoiceTitle     string `default:"Invoice" json:"text_reminder_invoice_title,omitempty"`
+	TextReminderDateTitle        string `default:"Date" json:"text_reminder_date_title,omitempty"`
+	TextReminderDueDateTitle     string `default:"Due date" json:"text_reminder_due_date_title,omitempty"`
+	TextReminderDaysOverdueTitle string `default:"Days overdue" json:"text_reminder_days_overdue_title,omitempty"`
+	TextReminderAmountTitle      string `default:"Outstanding" json:"text_reminder_amount_title,omitempty"`
+	TextReminderInterestTitle    string `default:"Interest" json:"text_reminder_interest_title,omitempty"`
+	TextReminderFeeTitle         string `default:"Fee" json:"text_reminder_fee_title,omitempty"`
+	TextReminderTotalOutstanding string `default:"OUTSTANDING" json:"text_reminder_total_outstanding,omitempty"`
+	TextReminderTotalInterest    string `default:"INTEREST" json:"text_reminder_total_interest,omitempty"`
+	TextReminderTotalFees        string `default:"FEES" json:"text_reminder_total_fees,omitempty"`
+	TextReminderTotalDue         string `default:"TOTAL DUE" json:"text_reminder_total_due,omitempty"`
+
+	TextStatementDateTitle          string `default:"Date" json:"text_statement_date_title,omitempty"`
+	TextStatementRefTitle           string `default:"Ref" json:"text_statement_ref_title,omitempty"`
+	TextStatementDescriptionTitle   string `default:"Description" json:"text_statement_description_title,omitempty"`
+	TextStatementDueDateTitle       string `default:"Due date" json:"text_statement_due_date_title,omitempty"`
+	TextStatementDebitTitle         string `default:"Debit" json:"text_statement_debit_title,omitempty"`
+	TextStatementCreditTitle        string `default:"Credit" json:"text_statement_credit_title,omitempty"`
+	TextStatementBalanceTitle       string `default:"Balance" json:"text_statement_balance_title,omitempty"`
+	TextStatementOpeningBalance     string `default:"Opening balance" json:"text_statement_opening_balance,omitempty"`
+	TextStatementCarriedForward     string `default:"Carried forward" json:"text_statement_carried_forward,omitempty"`
+	TextStatementBroughtForward     string `default:"Brought forward" json:"text_statement_brought_forward,omitempty"`
+	TextStatementAgeingCurrentTitle string `default:"Current" json:"text_statement_ageing_current_title,omitempty"`
+	TextStatementAgeing30Title      string `default:"30 days" json:"text_statement_ageing_30_title,omitempty"`
+	TextStatementAgeing60Title      string `default:"60 days" json:"text_statement_ageing_60_title,omitempty"`
+	TextStatementAgeing90Title      string `default:"90+ days" json:"text_statement_ageing_90_title,omitempty"`
+	TextStatementTotalBalance       string `default:"BALANCE DUE" json:"text_statement_total_balance,omitempty"`
+
+	TextIBANTitle     string `default:"IBAN" json:"text_iban_title,omitempty"`
+	TextBICTitle      string `default:"BIC" json:"text_bic_title,omitempty"`
+	TextBankNameTitle string `default:"Bank" json:"text_bank_name_title,omitempty"`
+	TextCurrencyTitle string `default:"Currency" json:"text_currency_title,omitempty"`
 
-	BaseTextColor []uint8 `default:"[35,35,35]" json:"base_text_color,omitempty"`
-	GreyTextColor []uint8 `default:"[82,82,82]" json:"grey_text_color,omitempty"`
-	GreyBgColor   []uint8 `default:"[232,232,232]" json:"grey_bg_color,omitempty"`
-	DarkBgColor   []uint8 `default:"[192,192,192]" json:"dark_bg_color,omitempty"`
+	BaseTextColor  []uint8 `default:"[35,35,35]" json:"base_text_color,omitempty"`
+	GreyTextColor  []uint8 `default:"[82,82,82]" json:"grey_text_color,omitempty"`
+	MutedTextColor []uint8 `default:"[150,150,150]" json:"muted_text_color,omitempty"` // Optional and alternative items
+	GreyBgColor    []uint8 `default:"[232,232,232]" json:"grey_bg_color,omitempty"`
+	DarkBgColor    []uint8 `default:"[192,192,192]" json:"dark_bg_color,omitempty"`
 }
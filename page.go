@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"github.com/signintech/gopdf"
+)
+
+// columnsGap define the space between the company and customer columns
+const columnsGap = 32
+
+// pageSize return the page size from options, swapped in landscape
+func (o *Options) pageSize() gopdf.Rect {
+	size := *gopdf.PageSizeA4
+	switch o.PageSize {
+	case PageSizeLetter:
+		size = *gopdf.PageSizeLetter
+	case PageSizeLegal:
+		size = *gopdf.PageSizeLegal
+	}
+
+	if o.Orientation == OrientationLandscape {
+		size.W, size.H = size.H, size.W
+	}
+
+	return size
+}
+
+func (doc *Document) pageWidth() float64 {
+	return doc.Options.pageSize().W
+}
+
+func (doc *Document) pageHeight() float64 {
+	return doc.Options.pageSize().H
+}
+
+// contentWidth return the page width between margins
+func (doc *Document) contentWidth() float64 {
+	return doc.pageWidth() - doc.Options.MarginLeft - doc.Options.MarginRight
+}
+
+// contentRight return the x of the right margin
+func (doc *Document) contentRight() float64 {
+	return doc.pageWidth() - doc.Options.MarginRight
+}
+
+// contentBottom return the y of the bottom margin, content must not go below
+func (doc *Document) contentBottom() float64 {
+	return doc.pageHeight() - doc.Options.MarginBottom
+}
+
+// columnWidth return the width of the company and customer columns
+func (doc *Document) columnWidth() float64 {
+	return (doc.contentWidth() - columnsGap) / 2
+}
+
+// rightColumnX return the x of the right column, used by title, customer and totals
+func (doc *Document) rightColumnX() float64 {
+	return doc.contentRight() - doc.columnWidth()
+}
+
+// isRTL return true when the layout is mirrored for right to left languages
+func (doc *Document) isRTL() bool {
+	return doc.Options.Direction == DirectionRTL
+}
+
+// drawX return the x where a box of width w placed at x is drawn, mirrored in right to left documents.
+// Layout is computed left to right, drawing helpers mirror it.
+func (doc *Document) drawX(x float64, w float64) float64 {
+	if doc.isRTL() {
+		return doc.pageWidth() - x - w
+	}
+
+	return x
+}
+
+// rectangle draws a rectangle from its corners with a style ("F" filled, "D" stroked)
+func (doc *Document) rectangle(x0 float64, y0 float64, x1 float64, y1 float64, style string) {
+	x := doc.drawX(x0, x1-x0)
+	_ = doc.pdf.Rectangle(x, y0, x+x1-x0, y1, style, 0, 0)
+}
+
+// mirrorAlign swaps left and right alignments
+func mirrorAlign(align int) int {
+	switch {
+	case align&gopdf.Right == gopdf.Right:
+		return align&^gopdf.Right | gopdf.Left
+	case align&gopdf.Center == gopdf.Center:
+		return align
+	}
+
+	return align&^gopdf.Left | gopdf.Right
+}
@@ -0,0 +1,59 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestPageLayout(t *testing.T) {
+	expected := []struct {
+		pageSize    string
+		orientation string
+		width       float64
+		height      float64
+	}{
+		{PageSizeA4, OrientationPortrait, 595, 842},
+		{PageSizeLetter, OrientationPortrait, 612, 792},
+		{PageSizeLegal, OrientationPortrait, 612, 1008},
+		{PageSizeLetter, OrientationLandscape, 792, 612},
+	}
+	for _, e := range expected {
+		doc, _ := New(Invoice, &Options{PageSize: e.pageSize, Orientation: e.orientation})
+		if doc.pageWidth() != e.width || doc.pageHeight() != e.height {
+			t.Errorf("%s %s: expected %vx%v, got %vx%v", e.pageSize, e.orientation, e.width, e.height, doc.pageWidth(), doc.pageHeight())
+		}
+	}
+
+	doc, _ := New(Invoice, &Options{MarginLeft: 50, MarginRight: 40, MarginBottom: 60})
+	if width := doc.contentWidth(); width != 595-50-40 {
+		t.Errorf("unexpected content width %v", width)
+	}
+	if bottom := doc.contentBottom(); bottom != 842-60 {
+		t.Errorf("unexpected content bottom %v", bottom)
+	}
+	if x := doc.rightColumnX() + doc.columnWidth(); x != doc.contentRight() {
+		t.Errorf("right column must end at the right margin, ends at %v", x)
+	}
+}
+
+func TestPageSizeBuild(t *testing.T) {
+	doc, _ := New(Invoice, &Options{PageSize: PageSizeLetter, Orientation: OrientationLandscape})
+	doc.SetRef("ref")
+	doc.SetCompany(&Contact{Name: "Company", Address: &Address{Address: "Street 1"}})
+	doc.SetCustomer(&Contact{Name: "Customer", Address: &Address{Address: "Street 2"}})
+	for i := 0; i < 60; i++ {
+		doc.AppendItem(&Item{Name: "Service", UnitCost: "10", Quantity: "1"})
+	}
+
+	pdf, err := doc.Build()
+	if err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+	if pages := pdf.GetNumberOfPages(); pages < 2 {
+		t.Errorf("expected several pages, got %d", pages)
+	}
+
+	doc.Options.PageSize = "A5"
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for unknown page size")
+	}
+}
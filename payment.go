@@ -0,0 +1,47 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Payment define a payment already received for the document
+type Payment struct {
+	Date   string `json:"date,omitempty"`
+	Method string `json:"method,omitempty"` // Payment method ex Bank transfer
+	Amount string `json:"amount,omitempty" validate:"required"`
+	Ref    string `json:"ref,omitempty"` // Payment reference ex transaction ID
+}
+
+func (p *Payment) amount() decimal.Decimal {
+	amount, _ := decimal.NewFromString(p.Amount)
+	return amount
+}
+
+// description return the payment details shown in totals, ex "15/03/2022 - Bank transfer - TX123"
+func (p *Payment) description() string {
+	var parts []string
+	for _, part := range []string{p.Date, p.Method, p.Ref} {
+		if len(part) > 0 {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(parts, " - ")
+}
+
+// totalPaid return the sum of payments received
+func (doc *Document) totalPaid() decimal.Decimal {
+	total := decimal.NewFromFloat(0)
+	for _, payment := range doc.Payments {
+		total = total.Add(payment.amount())
+	}
+
+	return total
+}
+
+// balanceDue return the total payable minus payments received, it is the amount still to pay
+func (doc *Document) balanceDue() decimal.Decimal {
+	return doc.totalPayable().Sub(doc.totalPaid())
+}
@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestPayments(t *testing.T) {
+	doc := newTestDocument()
+	doc.SetDefaultTax(&Tax{Percent: "20"})
+	doc.AppendItem(&Item{Name: "Service", UnitCost: "500", Quantity: "2"})
+	doc.AppendPayment(&Payment{Date: "01/03/2022", Method: "Bank transfer", Amount: "400", Ref: "TX-1"})
+
+	if _, err := doc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+
+	if paid := doc.totalPaid(); !paid.Equal(decimalFromString("400")) {
+		t.Errorf("expected 400 paid, got %s", paid)
+	}
+	if balance := doc.balanceDue(); !balance.Equal(decimalFromString("800")) {
+		t.Errorf("expected 800 balance due, got %s", balance)
+	}
+	if description := doc.Payments[0].description(); description != "01/03/2022 - Bank transfer - TX-1" {
+		t.Errorf("unexpected payment description %s", description)
+	}
+
+	paidDoc := newTestDocument()
+	paidDoc.SetDefaultTax(&Tax{Percent: "20"})
+	paidDoc.AppendItem(&Item{Name: "Service", UnitCost: "500", Quantity: "2"})
+	paidDoc.AppendPayment(&Payment{Method: "Card", Amount: "1200"})
+
+	if _, err := paidDoc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+	if balance := paidDoc.balanceDue(); !balance.IsZero() {
+		t.Errorf("expected nothing left to pay, got %s", balance)
+	}
+
+	// Overpaid or within rounding of the currency precision is shown as paid
+	for _, amount := range []string{"1200", "1250", "1199.999"} {
+		paidDoc.Payments[0].Amount = amount
+		if row := balanceDueRow(paidDoc); row == nil || !row.paidStamp {
+			t.Errorf("expected the paid stamp for a payment of %s", amount)
+		}
+	}
+	paidDoc.Payments[0].Amount = "1199.99"
+	if row := balanceDueRow(paidDoc); row == nil || row.paidStamp {
+		t.Errorf("expected no paid stamp while a cent is due")
+	}
+
+	doc.AppendPayment(&Payment{Method: "Card"})
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for payment without amount")
+	}
+}
+
+func balanceDueRow(doc *Document) *totalRow {
+	for _, row := range doc.totalRows() {
+		if row.title == doc.Options.TextTotalBalanceDue {
+			return row
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/leekchan/accounting"
+	"github.com/shopspring/decimal"
+)
+
+// PriceTier define the unit cost of a quantity range, used by tiered items
+type PriceTier struct {
+	UpTo     string `json:"up_to,omitempty"`                         // Last quantity of the tier ex 1000, required empty for the last tier
+	UnitCost string `json:"unit_cost,omitempty" validate:"required"` // Unit cost in the tier ex 0.10
+	FlatFee  string `json:"flat_fee,omitempty"`                      // Fixed fee added when the tier is used ex 5
+}
+
+func (t *PriceTier) upTo() (decimal.Decimal, bool) {
+	if len(t.UpTo) == 0 {
+		return decimal.Decimal{}, false
+	}
+
+	upTo, _ := decimal.NewFromString(t.UpTo)
+	return upTo, true
+}
+
+func (t *PriceTier) unitCost() decimal.Decimal {
+	unitCost, _ := decimal.NewFromString(t.UnitCost)
+	return unitCost
+}
+
+func (t *PriceTier) flatFee() decimal.Decimal {
+	flatFee, _ := decimal.NewFromString(t.FlatFee)
+	return flatFee
+}
+
+// priceTierLine define the quantity billed in a price tier
+type priceTierLine struct {
+	tier     *PriceTier
+	over     decimal.Decimal // Upper bound of the previous tier, zero for the first one
+	quantity decimal.Decimal
+	total    decimal.Decimal
+}
+
+// quantityPlaces return the decimal places of a quantity, at least 0
+func quantityPlaces(quantity decimal.Decimal) int {
+	if exp := quantity.Exponent(); exp < 0 {
+		return int(-exp)
+	}
+
+	return 0
+}
+
+// formatQuantity return a tier quantity with at least the given decimal places
+func formatQuantity(ac accounting.Accounting, value decimal.Decimal, places int) string {
+	if p := quantityPlaces(value); p > places {
+		places = p
+	}
+
+	return accounting.FormatNumberDecimal(value, places, ac.Thousand, ac.Decimal)
+}
+
+// tierRange return the tier bounds as text, ex "over 1,000 up to 10,000"
+func (l *priceTierLine) tierRange(ac accounting.Accounting, options *Options, places int) string {
+	var bounds []string
+	if l.over.IsPositive() {
+		bounds = append(bounds, fmt.Sprintf("%s %s", options.TextItemTierOver, formatQuantity(ac, l.over, places)))
+	}
+	if upTo, ok := l.tier.upTo(); ok {
+		bounds = append(bounds, fmt.Sprintf("%s %s", options.TextItemTierUpTo, formatQuantity(ac, upTo, places)))
+	}
+
+	return strings.Join(bounds, " ")
+}
+
+// description return the tier line as text, ex "over 1,000 up to 10,000: 9,000 × €0.08 = €720.00"
+func (l *priceTierLine) description(ac accounting.Accounting, options *Options, places int) string {
+	description := fmt.Sprintf(
+		"%s × %s",
+		formatQuantity(ac, l.quantity, places),
+		ac.FormatMoneyDecimal(l.tier.unitCost()),
+	)
+	if tierRange := l.tierRange(ac, options, places); len(tierRange) > 0 {
+		description = fmt.Sprintf("%s: %s", tierRange, description)
+	}
+	if flatFee := l.tier.flatFee(); !flatFee.IsZero() {
+		description = fmt.Sprintf("%s + %s", description, ac.FormatMoneyDecimal(flatFee))
+	}
+
+	return fmt.Sprintf("%s = %s", description, ac.FormatMoneyDecimal(l.total))
+}
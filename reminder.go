@@ -0,0 +1,179 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/signintech/gopdf"
+)
+
+// Dunning define the dunning informations of a payment reminder document
+type Dunning struct {
+	Level        int            `json:"level,omitempty" validate:"min=0"`            // Dunning level ex 2
+	InterestRate string         `json:"interest_rate,omitempty"`                     // Annual interest rate in percent ex 9.12
+	Fee          string         `json:"fee,omitempty"`                               // Fixed fee of the reminder ex 5
+	Invoices     []*OpenInvoice `json:"invoices,omitempty" validate:"required,dive"` // Invoices not paid
+}
+
+// OpenInvoice define an invoice not paid, listed in a reminder
+type OpenInvoice struct {
+	Ref     string `json:"ref,omitempty" validate:"required"`
+	Date    string `json:"date,omitempty"`
+	DueDate string `json:"due_date,omitempty" validate:"required"`
+	Amount  string `json:"amount,omitempty" validate:"required"` // Outstanding amount ex 1200.50
+	Fee     string `json:"fee,omitempty"`                        // Fixed fee ex late payment compensation 40
+}
+
+func (r *Dunning) interestRate() decimal.Decimal {
+	interestRate, _ := decimal.NewFromString(r.InterestRate)
+	return interestRate
+}
+
+func (r *Dunning) fee() decimal.Decimal {
+	fee, _ := decimal.NewFromString(r.Fee)
+	return fee
+}
+
+func (o *OpenInvoice) amount() decimal.Decimal {
+	amount, _ := decimal.NewFromString(o.Amount)
+	return amount
+}
+
+func (o *OpenInvoice) fee() decimal.Decimal {
+	fee, _ := decimal.NewFromString(o.Fee)
+	return fee
+}
+
+// daysOverdue return the days from due date to date, zero when not due yet
+func (o *OpenInvoice) daysOverdue(date time.Time, layout string) int {
+	dueDate, err := time.Parse(layout, o.DueDate)
+	if err != nil {
+		return 0
+	}
+
+	days := daysBetween(dueDate, date)
+	if days < 0 {
+		return 0
+	}
+
+	return days
+}
+
+// interest return the interest from due date to date at an annual rate in percent, on a 365 days year
+func (o *OpenInvoice) interest(date time.Time, layout string, rate decimal.Decimal, precision int32) decimal.Decimal {
+	days := decimal.NewFromInt(int64(o.daysOverdue(date, layout)))
+	return o.amount().Mul(rate).Mul(days).Div(decimal.NewFromFloat(36500)).Round(precision)
+}
+
+// reminderOutstanding return the sum of open invoices amounts
+func (doc *Document) reminderOutstanding() decimal.Decimal {
+	total := decimal.NewFromFloat(0)
+	for _, invoice := range doc.Dunning.Invoices {
+		total = total.Add(invoice.amount())
+	}
+
+	return total
+}
+
+// reminderInterest return the sum of open invoices interests at the reminder date
+func (doc *Document) reminderInterest() decimal.Decimal {
+	total := decimal.NewFromFloat(0)
+	for _, invoice := range doc.Dunning.Invoices {
+		total = total.Add(invoice.interest(
+			doc.date(),
+			doc.Options.DateFormat,
+			doc.Dunning.interestRate(),
+			int32(doc.Options.CurrencyPrecision),
+		))
+	}
+
+	return total
+}
+
+// reminderFees return the reminder fee plus open invoices fees
+func (doc *Document) reminderFees() decimal.Decimal {
+	total := doc.Dunning.fee()
+	for _, invoice := range doc.Dunning.Invoices {
+		total = total.Add(invoice.fee())
+	}
+
+	return total
+}
+
+// reminderTotal return the total due: outstanding amounts, interests and fees
+func (doc *Document) reminderTotal() decimal.Decimal {
+	return doc.reminderOutstanding().Add(doc.reminderInterest()).Add(doc.reminderFees())
+}
+
+func (doc *Document) reminderColumns() []*tableColumn {
+	return []*tableColumn{
+		{title: doc.Options.TextReminderInvoiceTitle, offset: 0, width: 0.2, align: gopdf.Left},
+		{title: doc.Options.TextReminderDateTitle, offset: 0.2, width: 0.13, align: gopdf.Left},
+		{title: doc.Options.TextReminderDueDateTitle, offset: 0.33, width: 0.13, align: gopdf.Left},
+		{title: doc.Options.TextReminderDaysOverdueTitle, offset: 0.46, width: 0.12, align: gopdf.Right},
+		{title: doc.Options.TextReminderAmountTitle, offset: 0.58, width: 0.16, align: gopdf.Right},
+		{title: doc.Options.TextReminderInterestTitle, offset: 0.74, width: 0.13, align: gopdf.Right},
+		{title: doc.Options.TextReminderFeeTitle, offset: 0.87, width: 0.13, align: gopdf.Right},
+	}
+}
+
+func (doc *Document) appendOpenInvoices() {
+	ac := doc.accounting()
+
+	columns := doc.reminderColumns()
+
+	doc.pdf.SetY(doc.pdf.GetY() + itemsPaddingTop)
+	doc.drawsColumnsTitles(columns)
+
+	for _, invoice := range doc.Dunning.Invoices {
+		interest := invoice.interest(
+			doc.date(),
+			doc.Options.DateFormat,
+			doc.Dunning.interestRate(),
+			int32(doc.Options.CurrencyPrecision),
+		)
+
+		doc.appendTableRow(columns, []string{
+			invoice.Ref,
+			invoice.Date,
+			invoice.DueDate,
+			strconv.Itoa(invoice.daysOverdue(doc.date(), doc.Options.DateFormat)),
+			ac.FormatMoneyDecimal(invoice.amount()),
+			ac.FormatMoneyDecimal(interest),
+			ac.FormatMoneyDecimal(invoice.fee()),
+		})
+	}
+}
+
+func (doc *Document) appendReminderTotal() {
+	doc.appendTotalRows(doc.reminderTotalRows())
+}
+
+// reminderTotalRows return the rows of the totals bloc of reminders
+func (doc *Document) reminderTotalRows() []*totalRow {
+	ac := doc.accounting()
+
+	// OUTSTANDING
+	rows := []*totalRow{{title: doc.Options.TextReminderTotalOutstanding, value: ac.FormatMoneyDecimal(doc.reminderOutstanding())}}
+
+	// INTEREST
+	if rate := doc.Dunning.interestRate(); !rate.IsZero() {
+		rows = append(rows, &totalRow{
+			title:       doc.Options.TextReminderTotalInterest,
+			description: fmt.Sprintf("%s %%", rate),
+			value:       ac.FormatMoneyDecimal(doc.reminderInterest()),
+		})
+	}
+
+	// FEES
+	if fees := doc.reminderFees(); !fees.IsZero() {
+		rows = append(rows, &totalRow{title: doc.Options.TextReminderTotalFees, value: ac.FormatMoneyDecimal(fees)})
+	}
+
+	// TOTAL DUE
+	rows = append(rows, &totalRow{title: doc.Options.TextReminderTotalDue, value: ac.FormatMoneyDecimal(doc.reminderTotal())})
+
+	return rows
+}
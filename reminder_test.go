@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReminder(t *testing.T) {
+	doc := newTestDocument()
+	doc.SetType(Reminder)
+	doc.SetDate("31/03/2022")
+	doc.SetDunning(&Dunning{
+		Level:        2,
+		InterestRate: "9.12",
+		Fee:          "5",
+		Invoices: []*OpenInvoice{
+			{Ref: "INV-1", Date: "01/01/2022", DueDate: "31/01/2022", Amount: "1000", Fee: "40"},
+			{Ref: "INV-2", Date: "01/03/2022", DueDate: "31/03/2022", Amount: "500"},
+			{Ref: "INV-3", Date: "01/03/2022", DueDate: "15/04/2022", Amount: "200"},
+		},
+	})
+
+	if _, err := doc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+
+	if days := doc.Dunning.Invoices[0].daysOverdue(doc.date(), doc.Options.DateFormat); days != 59 {
+		t.Errorf("expected 59 days overdue, got %d", days)
+	}
+	if days := doc.Dunning.Invoices[2].daysOverdue(doc.date(), doc.Options.DateFormat); days != 0 {
+		t.Errorf("expected invoice not due yet, got %d days overdue", days)
+	}
+
+	expected := []struct {
+		name   string
+		actual string
+		amount string
+	}{
+		{"outstanding", doc.reminderOutstanding().String(), "1700"},
+		{"interest", doc.reminderInterest().String(), "14.74"},
+		{"fees", doc.reminderFees().String(), "45"},
+		{"total", doc.reminderTotal().String(), "1759.74"},
+	}
+	for _, e := range expected {
+		if !decimalFromString(e.actual).Equal(decimalFromString(e.amount)) {
+			t.Errorf("%s: expected %s, got %s", e.name, e.amount, e.actual)
+		}
+	}
+
+	// A 23 hours day of a daylight saving time change is still a day
+	before, after := time.FixedZone("CET", 3600), time.FixedZone("CEST", 7200)
+	if days := daysBetween(time.Date(2022, 3, 26, 0, 0, 0, 0, before), time.Date(2022, 3, 27, 0, 0, 0, 0, after)); days != 1 {
+		t.Errorf("expected 1 day over the time change, got %d", days)
+	}
+
+	if title := doc.typeAsString(); title != "PAYMENT REMINDER - LEVEL 2" {
+		t.Errorf("unexpected title %s", title)
+	}
+
+	doc.SetDate("2022-03-31")
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for unparsable document date")
+	}
+
+	doc.SetDate("31/03/2022")
+	doc.Dunning.Invoices[0].DueDate = "2022-01-31"
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for unparsable due date")
+	}
+
+	doc.SetDunning(nil)
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for missing dunning")
+	}
+}
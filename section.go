@@ -0,0 +1,128 @@
+package generator
+
+import (
+	"math"
+
+	"github.com/shopspring/decimal"
+)
+
+// isLine return true when the item is a billed line, not a section heading or subtotal
+func (i *Item) isLine() bool {
+	return len(i.Type) == 0
+}
+
+// lineItems return the billed items of the document, without section headings and subtotals
+func (doc *Document) lineItems() []*Item {
+	var items []*Item
+	for _, item := range doc.Items {
+		if item.isLine() {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// sectionItems return the lines of the section closed by the subtotal at index k,
+// from the previous heading or subtotal
+func (doc *Document) sectionItems(k int) []*Item {
+	start := 0
+	for j := k - 1; j >= 0; j-- {
+		if !doc.Items[j].isLine() {
+			start = j + 1
+			break
+		}
+	}
+
+	return doc.Items[start:k]
+}
+
+// appendSectionHeading draws a section heading across the whole items table
+func (doc *Document) appendSectionHeading(item *Item) {
+	doc.setFont("B", itemFontSize)
+	doc.itemMultiCell(doc.sectionHeadingBox(), item.Name)
+	doc.setFont("", itemFontSize)
+}
+
+// sectionHeadingBox return the box of section headings, spanning the whole items table
+func (doc *Document) sectionHeadingBox() *itemColumnBox {
+	return &itemColumnBox{
+		column: &ItemColumn{Key: ItemColumnName},
+		x:      doc.Options.MarginLeft,
+		w:      doc.contentWidth(),
+	}
+}
+
+// sectionSubtotalBox return the column of a subtotal title, the name column or the first one when names are not shown.
+// Without columns the title spans the whole items table.
+func (doc *Document) sectionSubtotalBox(columns []*itemColumnBox) *itemColumnBox {
+	if len(columns) == 0 {
+		return doc.sectionHeadingBox()
+	}
+
+	for _, box := range columns {
+		if box.column.Key == ItemColumnName {
+			return box
+		}
+	}
+
+	return columns[0]
+}
+
+// sectionHeight return the height of a section heading or subtotal line
+func (doc *Document) sectionHeight(item *Item, columns []*itemColumnBox) float64 {
+	defer doc.measureFont("B", itemFontSize)()
+
+	if item.Type == ItemHeading {
+		return doc.itemMultiCellHeight(doc.sectionHeadingBox(), item.Name)
+	}
+
+	return math.Max(itemFontSize, doc.itemMultiCellHeight(doc.sectionSubtotalBox(columns), item.Name))
+}
+
+// appendSectionSubtotal draws the subtotal of a section lines under a separator
+func (doc *Document) appendSectionSubtotal(item *Item, items []*Item, columns []*itemColumnBox) {
+	ac := doc.accounting()
+
+	totalWithoutTax := decimal.NewFromFloat(0)
+	totalWithTax := decimal.NewFromFloat(0)
+	for _, line := range items {
+		if !line.isBilled() {
+			continue
+		}
+
+		totalWithoutTax = totalWithoutTax.Add(line.totalWithoutTax(doc))
+		totalWithTax = totalWithTax.Add(line.totalWithTaxAndDiscount(doc))
+	}
+
+	// Separator
+	doc.pdf.SetFillColor(doc.Options.DarkBgColor[0], doc.Options.DarkBgColor[1], doc.Options.DarkBgColor[2])
+	doc.rectangle(doc.Options.MarginLeft, doc.pdf.GetY()-3.5, doc.contentRight(), doc.pdf.GetY()-3, "F")
+
+	baseY := doc.pdf.GetY()
+	height := doc.sectionHeight(item, columns)
+	doc.setFont("B", itemFontSize)
+
+	// Title in the name column, or in the first one when names are not shown
+	titleBox := doc.sectionSubtotalBox(columns)
+	doc.itemMultiCell(titleBox, item.Name)
+
+	for _, box := range columns {
+		if box == titleBox {
+			continue
+		}
+
+		doc.pdf.SetY(baseY)
+		doc.pdf.SetX(box.textX())
+
+		switch box.column.Key {
+		case ItemColumnTotalWithoutTax:
+			doc.itemCell(box, height, ac.FormatMoneyDecimal(totalWithoutTax))
+		case ItemColumnTotalWithTax:
+			doc.itemCell(box, height, ac.FormatMoneyDecimal(totalWithTax))
+		}
+	}
+
+	doc.setFont("", itemFontSize)
+	doc.pdf.SetY(baseY + height)
+}
@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestItemSections(t *testing.T) {
+	doc := newTestDocument()
+	doc.SetDefaultTax(&Tax{Percent: "20"})
+	doc.AppendHeading("Design")
+	doc.AppendItem(&Item{Name: "Mockups", UnitCost: "100", Quantity: "3"})
+	doc.AppendItem(&Item{Name: "Review", UnitCost: "50", Quantity: "1"})
+	doc.AppendSubtotal("Design subtotal")
+	doc.AppendHeading("Development")
+	doc.AppendItem(&Item{Name: "Backend", UnitCost: "200", Quantity: "2"})
+	doc.AppendSubtotal("Development subtotal")
+
+	if items := doc.sectionItems(3); len(items) != 2 || items[0].Name != "Mockups" {
+		t.Errorf("expected the 2 design lines in the first section, got %d", len(items))
+	}
+	if items := doc.sectionItems(6); len(items) != 1 || items[0].Name != "Backend" {
+		t.Errorf("expected the backend line in the second section, got %d", len(items))
+	}
+
+	if _, err := doc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+
+	if total := doc.totalWithoutTax(); !total.Equal(decimalFromString("750")) {
+		t.Errorf("expected total 750, got %s", total)
+	}
+	if groups := doc.taxGroups(); len(groups) != 1 || !groups[0].amount.Equal(decimalFromString("150")) {
+		t.Errorf("expected a single tax group of 150, got %d groups", len(groups))
+	}
+	if doc.Items[0].Tax != nil {
+		t.Errorf("expected no default tax on section headings")
+	}
+
+	// Subtotal titles span the table without columns
+	if box := doc.sectionSubtotalBox(nil); box.w != doc.contentWidth() {
+		t.Errorf("expected a full width subtotal title, got %v", box.w)
+	}
+	if height := doc.sectionHeight(doc.Items[3], nil); height <= 0 {
+		t.Errorf("unexpected subtotal height %v", height)
+	}
+
+	doc.Items[0].Type = "section"
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for unknown item type")
+	}
+}
+
+func TestItemSectionsPageBreak(t *testing.T) {
+	doc := newTestDocument()
+	for s := 0; s < 6; s++ {
+		doc.AppendHeading("Phase")
+		for k := 0; k < 10; k++ {
+			doc.AppendItem(&Item{Name: "Work", UnitCost: "10", Quantity: "1"})
+		}
+		doc.AppendSubtotal("Phase subtotal")
+	}
+
+	pdf, err := doc.Build()
+	if err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+	if pages := pdf.GetNumberOfPages(); pages < 2 {
+		t.Errorf("expected the sections to span several pages, got %d", pages)
+	}
+}
@@ -60,6 +60,18 @@ func (d *Document) AppendItem(item *Item) *Document {
 	return d
 }
 
+// AppendHeading to document items, starting a new section
+func (d *Document) AppendHeading(name string) *Document {
+	d.Items = append(d.Items, &Item{Type: ItemHeading, Name: name})
+	return d
+}
+
+// AppendSubtotal to document items, closing the current section
+func (d *Document) AppendSubtotal(name string) *Document {
+	d.Items = append(d.Items, &Item{Type: ItemSubtotal, Name: name})
+	return d
+}
+
 // SetDate of document
 func (d *Document) SetDate(date string) *Document {
 	d.Date = date
@@ -72,14 +84,74 @@ func (d *Document) SetPaymentTerm(term string) *Document {
 	return d
 }
 
+// AppendCashDiscount to document payment terms
+func (d *Document) AppendCashDiscount(cashDiscount *CashDiscount) *Document {
+	d.CashDiscounts = append(d.CashDiscounts, cashDiscount)
+	return d
+}
+
 // SetDefaultTax of document
 func (d *Document) SetDefaultTax(tax *Tax) *Document {
 	d.DefaultTax = tax
 	return d
 }
 
+// SetPricesIncludeTax of document
+func (d *Document) SetPricesIncludeTax(pricesIncludeTax bool) *Document {
+	d.PricesIncludeTax = pricesIncludeTax
+	return d
+}
+
+// SetShowOptionalTotal of document
+func (d *Document) SetShowOptionalTotal(showOptionalTotal bool) *Document {
+	d.ShowOptionalTotal = showOptionalTotal
+	return d
+}
+
 // SetDiscount of document
 func (d *Document) SetDiscount(discount *Discount) *Document {
 	d.Discount = discount
 	return d
 }
+
+// AppendCharge to document charges and allowances
+func (d *Document) AppendCharge(charge *Charge) *Document {
+	d.Charges = append(d.Charges, charge)
+	return d
+}
+
+// SetWithholding of document
+func (d *Document) SetWithholding(withholding *Withholding) *Document {
+	d.Withholding = withholding
+	return d
+}
+
+// SetDunning of document
+func (d *Document) SetDunning(dunning *Dunning) *Document {
+	d.Dunning = dunning
+	return d
+}
+
+// SetStatement of document
+func (d *Document) SetStatement(statement *AccountStatement) *Document {
+	d.Statement = statement
+	return d
+}
+
+// AppendPayment to document payments
+func (d *Document) AppendPayment(payment *Payment) *Document {
+	d.Payments = append(d.Payments, payment)
+	return d
+}
+
+// SetWatermark of document
+func (d *Document) SetWatermark(watermark *Stamp) *Document {
+	d.Watermark = watermark
+	return d
+}
+
+// SetStamp of document
+func (d *Document) SetStamp(stamp *Stamp) *Document {
+	d.Stamp = stamp
+	return d
+}
@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"github.com/signintech/gopdf"
+)
+
+// Stamp define a status text drawn as a watermark across pages or as a stamp box near the title
+type Stamp struct {
+	Text    string  `json:"text,omitempty" validate:"required"`
+	Color   []uint8 `json:"color,omitempty" validate:"omitempty,len=3"`
+	Opacity float64 `json:"opacity,omitempty" validate:"min=0,max=1"` // From 0 to 1, defaults to 0.15 for watermarks and 0.8 for stamps
+}
+
+// stampColors define the colors of the stamp presets
+var stampColors = map[string][]uint8{
+	StampDraft:     {120, 120, 120},
+	StampPaid:      {40, 150, 60},
+	StampCancelled: {200, 40, 40},
+	StampCopy:      {40, 80, 200},
+}
+
+const (
+	watermarkFontSize = 80
+	watermarkOpacity  = 0.15
+	stampWidth        = 100
+	stampFontSize     = 16
+	stampOpacity      = 0.8
+)
+
+// NewStamp return a stamp preset, ex StampDraft
+func NewStamp(preset string) *Stamp {
+	return &Stamp{
+		Text:  preset,
+		Color: stampColors[preset],
+	}
+}
+
+func (s *Stamp) color() []uint8 {
+	if len(s.Color) == 3 {
+		return s.Color
+	}
+
+	return []uint8{0, 0, 0}
+}
+
+func (s *Stamp) opacity(defaultOpacity float64) float64 {
+	if s.Opacity > 0 {
+		return s.Opacity
+	}
+
+	return defaultOpacity
+}
+
+// addPage adds a page to the document and draws the watermark on it
+func (doc *Document) addPage() {
+	doc.pdf.AddPage()
+	doc.appendWatermark()
+}
+
+// appendWatermark draws the watermark rotated across the current page
+func (doc *Document) appendWatermark() {
+	if doc.Watermark == nil {
+		return
+	}
+
+	x, y := doc.pdf.GetX(), doc.pdf.GetY()
+	color := doc.Watermark.color()
+	centerX, centerY := doc.pageWidth()/2, doc.pageHeight()/2
+
+	_ = doc.pdf.SetTransparency(gopdf.Transparency{Alpha: doc.Watermark.opacity(watermarkOpacity), BlendModeType: gopdf.NormalBlendMode})
+	doc.pdf.SetTextColor(color[0], color[1], color[2])
+	doc.setFont("", watermarkFontSize)
+	doc.pdf.Rotate(45, centerX, centerY)
+	doc.pdf.SetX(0)
+	doc.pdf.SetY(centerY - watermarkFontSize/2)
+	doc.cellWithOption(
+		&gopdf.Rect{W: doc.pageWidth(), H: watermarkFontSize},
+		doc.Watermark.Text,
+		gopdf.CellOption{Align: gopdf.Middle | gopdf.Center},
+	)
+	doc.pdf.RotateReset()
+	doc.pdf.ClearTransparency()
+
+	doc.pdf.SetTextColor(doc.Options.BaseTextColor[0], doc.Options.BaseTextColor[1], doc.Options.BaseTextColor[2])
+	doc.pdf.SetX(x)
+	doc.pdf.SetY(y)
+}
+
+// appendStamp draws the stamp box left of the title
+func (doc *Document) appendStamp() {
+	if doc.Stamp == nil {
+		return
+	}
+
+	doc.drawStamp(doc.Stamp, doc.rightColumnX()-stampWidth-10, doc.Options.MarginTop)
+}
+
+// appendPaidStamp draws a "PAID" stamp left of the last totals row
+func (doc *Document) appendPaidStamp() {
+	stamp := NewStamp(StampPaid)
+	stamp.Text = doc.Options.TextStampPaid
+
+	y := doc.pdf.GetY() - stampFontSize - totalMargin*2
+	doc.drawStamp(stamp, doc.rightColumnX()-stampWidth-10, y)
+	doc.pdf.SetY(y + stampFontSize + totalMargin*2)
+}
+
+// drawStamp draws a slightly rotated stamp box from its upper left corner
+func (doc *Document) drawStamp(stamp *Stamp, x float64, y float64) {
+	color := stamp.color()
+	height := float64(stampFontSize + totalMargin*2)
+
+	_ = doc.pdf.SetTransparency(gopdf.Transparency{Alpha: stamp.opacity(stampOpacity), BlendModeType: gopdf.NormalBlendMode})
+	doc.pdf.SetStrokeColor(color[0], color[1], color[2])
+	doc.pdf.SetTextColor(color[0], color[1], color[2])
+	doc.pdf.SetLineWidth(2)
+	doc.pdf.Rotate(10, doc.drawX(x, stampWidth)+stampWidth/2, y+height/2)
+	doc.rectangle(x, y, x+stampWidth, y+height, "D")
+
+	doc.pdf.SetX(x)
+	doc.pdf.SetY(y)
+	doc.setFont("B", stampFontSize)
+	doc.cellWithOption(
+		&gopdf.Rect{W: stampWidth, H: height},
+		stamp.Text,
+		gopdf.CellOption{Align: gopdf.Middle | gopdf.Center},
+	)
+	doc.pdf.RotateReset()
+	doc.pdf.ClearTransparency()
+
+	doc.pdf.SetLineWidth(1)
+	doc.setFont("", LargeTextFontSize)
+	doc.pdf.SetTextColor(doc.Options.BaseTextColor[0], doc.Options.BaseTextColor[1], doc.Options.BaseTextColor[2])
+}
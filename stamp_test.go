@@ -0,0 +1,34 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestStamps(t *testing.T) {
+	for _, preset := range []string{StampDraft, StampPaid, StampCancelled, StampCopy} {
+		stamp := NewStamp(preset)
+		if stamp.Text != preset || len(stamp.color()) != 3 {
+			t.Errorf("unexpected %s preset: %+v", preset, stamp)
+		}
+	}
+
+	doc := newTestDocument()
+	doc.SetWatermark(NewStamp(StampDraft))
+	doc.SetStamp(&Stamp{Text: "DUPLICATE", Color: []uint8{0, 0, 255}, Opacity: 0.5})
+	for i := 0; i < 80; i++ {
+		doc.AppendItem(&Item{Name: "Service", UnitCost: "10", Quantity: "1"})
+	}
+
+	pdf, err := doc.Build()
+	if err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+	if pages := pdf.GetNumberOfPages(); pages < 2 {
+		t.Errorf("expected several pages, got %d", pages)
+	}
+
+	doc.Watermark.Opacity = 1.5
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for opacity above 1")
+	}
+}
@@ -0,0 +1,217 @@
+package generator
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/signintech/gopdf"
+)
+
+// AccountStatement define the ledger of a customer statement of account
+type AccountStatement struct {
+	OpeningBalance        string         `json:"opening_balance,omitempty"`          // Balance before the first entry ex 120.50
+	OpeningBalanceDueDate string         `json:"opening_balance_due_date,omitempty"` // Due date of a debit opening balance, aged from it
+	Entries               []*LedgerEntry `json:"entries,omitempty" validate:"dive"`
+}
+
+// LedgerEntry define an invoice, a credit note or a payment of a statement of account
+type LedgerEntry struct {
+	Type        string `json:"type,omitempty" validate:"required,oneof=INVOICE CREDIT_NOTE PAYMENT"`
+	Date        string `json:"date,omitempty" validate:"required"`
+	DueDate     string `json:"due_date,omitempty"` // Defaults to date
+	Ref         string `json:"ref,omitempty"`
+	Description string `json:"description,omitempty"`
+	Amount      string `json:"amount,omitempty" validate:"required"` // Positive amount ex 250
+}
+
+// ageing define the open balance by days overdue
+type ageing struct {
+	current  decimal.Decimal // Less than 30 days overdue
+	days30   decimal.Decimal // 30 to 59 days overdue
+	days60   decimal.Decimal // 60 to 89 days overdue
+	days90   decimal.Decimal // 90 days overdue and more
+	total    decimal.Decimal
+	balances []decimal.Decimal // Running balance after each entry
+}
+
+func (s *AccountStatement) openingBalance() decimal.Decimal {
+	openingBalance, _ := decimal.NewFromString(s.OpeningBalance)
+	return openingBalance
+}
+
+func (e *LedgerEntry) dueDate() string {
+	if len(e.DueDate) > 0 {
+		return e.DueDate
+	}
+
+	return e.Date
+}
+
+// amount return the signed amount of the entry, credits are negative
+func (e *LedgerEntry) amount() decimal.Decimal {
+	amount, _ := decimal.NewFromString(e.Amount)
+	if e.Type != EntryInvoice {
+		return amount.Neg()
+	}
+
+	return amount
+}
+
+// statementDaysOverdue return the calendar days from a due date to the document date
+func (doc *Document) statementDaysOverdue(dueDate string) int {
+	date, err := time.Parse(doc.Options.DateFormat, dueDate)
+	if err != nil {
+		return 0
+	}
+
+	return daysBetween(date, doc.date())
+}
+
+// statementAgeing return the running balances and ages the open balance at the document date.
+// Credits settle the oldest debits first, an opening debit balance is the oldest one and is aged from its due date.
+func (doc *Document) statementAgeing() *ageing {
+	a := &ageing{balances: make([]decimal.Decimal, len(doc.Statement.Entries))}
+
+	type debit struct {
+		days   int
+		amount decimal.Decimal
+	}
+
+	var debits []*debit
+	credit := decimal.NewFromFloat(0)
+	balance := doc.Statement.openingBalance()
+
+	if balance.IsPositive() {
+		debits = append(debits, &debit{days: doc.statementDaysOverdue(doc.Statement.OpeningBalanceDueDate), amount: balance})
+	} else {
+		credit = balance.Neg()
+	}
+
+	for k, entry := range doc.Statement.Entries {
+		balance = balance.Add(entry.amount())
+		a.balances[k] = balance
+
+		if entry.amount().IsPositive() {
+			debits = append(debits, &debit{days: doc.statementDaysOverdue(entry.dueDate()), amount: entry.amount()})
+		} else {
+			credit = credit.Add(entry.amount().Neg())
+		}
+	}
+
+	for _, d := range debits {
+		settled := decimal.Min(credit, d.amount)
+		credit = credit.Sub(settled)
+		open := d.amount.Sub(settled)
+
+		switch {
+		case d.days >= 90:
+			a.days90 = a.days90.Add(open)
+		case d.days >= 60:
+			a.days60 = a.days60.Add(open)
+		case d.days >= 30:
+			a.days30 = a.days30.Add(open)
+		default:
+			a.current = a.current.Add(open)
+		}
+	}
+
+	// Credits left are not overdue
+	a.current = a.current.Sub(credit)
+	a.total = balance
+
+	return a
+}
+
+func (doc *Document) statementColumns() []*tableColumn {
+	return []*tableColumn{
+		{title: doc.Options.TextStatementDateTitle, offset: 0, width: 0.12, align: gopdf.Left},
+		{title: doc.Options.TextStatementRefTitle, offset: 0.12, width: 0.14, align: gopdf.Left},
+		{title: doc.Options.TextStatementDescriptionTitle, offset: 0.26, width: 0.25, align: gopdf.Left},
+		{title: doc.Options.TextStatementDueDateTitle, offset: 0.51, width: 0.12, align: gopdf.Left},
+		{title: doc.Options.TextStatementDebitTitle, offset: 0.63, width: 0.12, align: gopdf.Right},
+		{title: doc.Options.TextStatementCreditTitle, offset: 0.75, width: 0.12, align: gopdf.Right},
+		{title: doc.Options.TextStatementBalanceTitle, offset: 0.87, width: 0.13, align: gopdf.Right},
+	}
+}
+
+func (doc *Document) appendLedgerEntries() {
+	ac := doc.accounting()
+
+	columns := doc.statementColumns()
+	ageing := doc.statementAgeing()
+	balance := doc.Statement.openingBalance()
+
+	doc.pdf.SetY(doc.pdf.GetY() + itemsPaddingTop)
+	doc.drawsColumnsTitles(columns)
+
+	// Opening balance
+	doc.appendTableRow(columns, []string{"", "", doc.Options.TextStatementOpeningBalance, doc.Statement.OpeningBalanceDueDate, "", "", ac.FormatMoneyDecimal(balance)})
+
+	for k, entry := range doc.Statement.Entries {
+		// Carry the balance forward to the next page, keeping room for the carried forward row
+		if !doc.tableRowsFit(2) {
+			doc.appendTableRow(columns, []string{"", "", doc.Options.TextStatementCarriedForward, "", "", "", ac.FormatMoneyDecimal(balance)})
+			doc.addPage()
+			doc.drawsColumnsTitles(columns)
+			doc.appendTableRow(columns, []string{"", "", doc.Options.TextStatementBroughtForward, "", "", "", ac.FormatMoneyDecimal(balance)})
+		}
+
+		debit, credit := "", ""
+		if amount := entry.amount(); amount.IsNegative() {
+			credit = ac.FormatMoneyDecimal(amount.Neg())
+		} else {
+			debit = ac.FormatMoneyDecimal(amount)
+		}
+
+		dueDate := ""
+		if entry.Type == EntryInvoice {
+			dueDate = entry.dueDate()
+		}
+
+		balance = ageing.balances[k]
+		doc.appendTableRow(columns, []string{
+			entry.Date,
+			entry.Ref,
+			entry.Description,
+			dueDate,
+			debit,
+			credit,
+			ac.FormatMoneyDecimal(balance),
+		})
+	}
+
+	// Ageing summary
+	ageingColumns := []*tableColumn{
+		{title: doc.Options.TextStatementAgeingCurrentTitle, offset: 0, width: 0.2, align: gopdf.Right},
+		{title: doc.Options.TextStatementAgeing30Title, offset: 0.2, width: 0.2, align: gopdf.Right},
+		{title: doc.Options.TextStatementAgeing60Title, offset: 0.4, width: 0.2, align: gopdf.Right},
+		{title: doc.Options.TextStatementAgeing90Title, offset: 0.6, width: 0.2, align: gopdf.Right},
+		{title: doc.Options.TextStatementBalanceTitle, offset: 0.8, width: 0.2, align: gopdf.Right},
+	}
+
+	doc.pdf.SetY(doc.pdf.GetY() + 10)
+	if !doc.tableRowsFit(2) {
+		doc.addPage()
+	}
+
+	doc.drawsColumnsTitles(ageingColumns)
+	doc.appendTableRow(ageingColumns, []string{
+		ac.FormatMoneyDecimal(ageing.current),
+		ac.FormatMoneyDecimal(ageing.days30),
+		ac.FormatMoneyDecimal(ageing.days60),
+		ac.FormatMoneyDecimal(ageing.days90),
+		ac.FormatMoneyDecimal(ageing.total),
+	})
+}
+
+func (doc *Document) appendStatementTotal() {
+	doc.appendTotalRows(doc.statementTotalRows())
+}
+
+// statementTotalRows return the rows of the totals bloc of statements
+func (doc *Document) statementTotalRows() []*totalRow {
+	ac := doc.accounting()
+
+	// BALANCE DUE
+	return []*totalRow{{title: doc.Options.TextStatementTotalBalance, value: ac.FormatMoneyDecimal(doc.statementAgeing().total)}}
+}
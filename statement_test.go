@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestStatement(t *testing.T) {
+	doc := newTestDocument()
+	doc.SetType(Statement)
+	doc.SetDate("30/06/2022")
+	doc.SetStatement(&AccountStatement{
+		OpeningBalance:        "100",
+		OpeningBalanceDueDate: "31/03/2022",
+		Entries: []*LedgerEntry{
+			{Type: EntryInvoice, Date: "01/03/2022", DueDate: "31/03/2022", Ref: "INV-1", Amount: "300"},
+			{Type: EntryPayment, Date: "15/03/2022", Ref: "PAY-1", Amount: "250"},
+			{Type: EntryInvoice, Date: "01/04/2022", DueDate: "30/04/2022", Ref: "INV-2", Amount: "200"},
+			{Type: EntryCreditNote, Date: "10/04/2022", Ref: "CN-1", Amount: "50"},
+			{Type: EntryInvoice, Date: "01/05/2022", DueDate: "31/05/2022", Ref: "INV-3", Amount: "400"},
+			{Type: EntryInvoice, Date: "20/06/2022", DueDate: "20/07/2022", Ref: "INV-4", Amount: "150"},
+		},
+	})
+
+	if _, err := doc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+
+	ageing := doc.statementAgeing()
+	expected := []struct {
+		name   string
+		actual string
+		amount string
+	}{
+		{"balance", ageing.balances[3].String(), "300"},
+		{"current", ageing.current.String(), "150"},
+		{"30 days", ageing.days30.String(), "400"},
+		{"60 days", ageing.days60.String(), "200"},
+		{"90+ days", ageing.days90.String(), "100"},
+		{"total", ageing.total.String(), "850"},
+	}
+	for _, e := range expected {
+		if !decimalFromString(e.actual).Equal(decimalFromString(e.amount)) {
+			t.Errorf("%s: expected %s, got %s", e.name, e.amount, e.actual)
+		}
+	}
+
+	// The opening balance is aged from its own due date
+	opening := newTestDocument()
+	opening.SetType(Statement)
+	opening.SetDate("30/06/2022")
+	opening.SetStatement(&AccountStatement{OpeningBalance: "100", OpeningBalanceDueDate: "15/06/2022"})
+	if ageing := opening.statementAgeing(); !ageing.current.Equal(decimalFromString("100")) || !ageing.days90.IsZero() {
+		t.Errorf("expected a recent opening balance to be current, got %s current and %s 90+ days", ageing.current, ageing.days90)
+	}
+
+	doc.Statement.OpeningBalanceDueDate = ""
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for an opening balance without due date")
+	}
+	doc.Statement.OpeningBalanceDueDate = "31/03/2022"
+
+	doc.SetDate("")
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for missing document date")
+	}
+	doc.SetDate("2022-06-30")
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for unparsable document date")
+	}
+
+	doc.SetDate("30/06/2022")
+	doc.Statement.Entries[0].DueDate = "2022-03-31"
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for unparsable due date")
+	}
+}
+
+func TestStatementPages(t *testing.T) {
+	doc := newTestDocument()
+	doc.SetType(Statement)
+	doc.SetDate("30/06/2022")
+
+	statement := &AccountStatement{}
+	for i := 0; i < 100; i++ {
+		statement.Entries = append(statement.Entries, &LedgerEntry{
+			Type:   EntryInvoice,
+			Date:   "01/06/2022",
+			Ref:    fmt.Sprintf("INV-%d", i),
+			Amount: "10",
+		})
+	}
+	doc.SetStatement(statement)
+
+	pdf, err := doc.Build()
+	if err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+
+	if pages := pdf.GetNumberOfPages(); pages < 2 {
+		t.Errorf("expected several pages, got %d", pages)
+	}
+}
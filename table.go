@@ -0,0 +1,67 @@
+package generator
+
+import (
+	"github.com/signintech/gopdf"
+)
+
+// tableColumn define a column of a simple table, offset and width are ratios of the content width
+type tableColumn struct {
+	title  string
+	offset float64
+	width  float64
+	align  int
+}
+
+func (c *tableColumn) x(doc *Document) float64 {
+	return doc.Options.MarginLeft + doc.contentWidth()*c.offset + itemTitleMargin
+}
+
+func (c *tableColumn) w(doc *Document) float64 {
+	return doc.contentWidth()*c.width - itemTitleMargin*2
+}
+
+// drawsColumnsTitles draws the titles of a simple table
+func (doc *Document) drawsColumnsTitles(columns []*tableColumn) {
+	// Draw rec
+	doc.pdf.SetStrokeColor(doc.Options.GreyBgColor[0], doc.Options.GreyBgColor[1], doc.Options.GreyBgColor[2])
+	doc.pdf.SetFillColor(doc.Options.GreyBgColor[0], doc.Options.GreyBgColor[1], doc.Options.GreyBgColor[2])
+	doc.rectangle(
+		doc.Options.MarginLeft,
+		doc.pdf.GetY(),
+		doc.contentRight(),
+		doc.pdf.GetY()+itemFontSize+itemTitleMargin,
+		"F",
+	)
+
+	// Draw table titles
+	doc.pdf.SetY(doc.pdf.GetY() + itemTitleMargin/2)
+	doc.setFont("B", itemFontSize)
+	for _, column := range columns {
+		doc.pdf.SetX(column.x(doc))
+		doc.cellWithOption(&gopdf.Rect{W: column.w(doc), H: itemFontSize}, column.title, gopdf.CellOption{Align: column.align})
+	}
+
+	doc.pdf.SetY(doc.pdf.GetY() + itemFontSize + itemTitleMargin/2)
+	doc.setFont("", itemFontSize)
+}
+
+// tableRowsFit return true when rows of a simple table fit above the bottom of the page
+func (doc *Document) tableRowsFit(rows int) bool {
+	return doc.pdf.GetY()+(itemFontSize+itemTitleMargin)*float64(rows) <= doc.contentBottom()
+}
+
+// appendTableRow draws a row of a simple table, adding a page when the bottom is reached
+func (doc *Document) appendTableRow(columns []*tableColumn, values []string) {
+	if !doc.tableRowsFit(1) {
+		doc.addPage()
+		doc.drawsColumnsTitles(columns)
+	}
+
+	doc.pdf.SetY(doc.pdf.GetY() + itemTitleMargin/2)
+	for k, column := range columns {
+		doc.pdf.SetX(column.x(doc))
+		doc.cellWithOption(&gopdf.Rect{W: column.w(doc), H: itemFontSize}, values[k], gopdf.CellOption{Align: column.align})
+	}
+
+	doc.pdf.SetY(doc.pdf.GetY() + itemFontSize + itemTitleMargin/2)
+}
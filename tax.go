@@ -1,13 +1,20 @@
 package generator
 
 import (
+	"strings"
+
 	"github.com/shopspring/decimal"
 )
 
 // Tax define tax as percent or fixed amount
 type Tax struct {
-	Percent string `json:"percent,omitempty"` // Tax in percent ex 17
-	Amount  string `json:"amount,omitempty"`  // Tax in amount ex 123.40
+	Name                string `json:"name,omitempty"`                                           // Tax name ex GST
+	Percent             string `json:"percent,omitempty"`                                        // Tax in percent ex 17
+	Amount              string `json:"amount,omitempty"`                                         // Tax in amount ex 123.40
+	Category            string `json:"category,omitempty" validate:"omitempty,oneof=S Z E AE O"` // Tax category ex AE, defaults to S
+	ExemptionReasonCode string `json:"exemption_reason_code,omitempty"`                          // Exemption reason code ex VATEX-EU-AE
+	ExemptionReason     string `json:"exemption_reason,omitempty"`                               // Exemption reason text
+	Compound            bool   `json:"compound,omitempty"`                                       // Computed on the base plus the previous taxes
 }
 
 func (t *Tax) getTax() (string, decimal.Decimal) {
@@ -27,3 +34,116 @@ func (t *Tax) getTax() (string, decimal.Decimal) {
 
 	return taxType, decVal
 }
+
+func (t *Tax) category() string {
+	if len(t.Category) == 0 {
+		return TaxCategoryStandard
+	}
+
+	return t.Category
+}
+
+// groupKey identify taxes of the same name, category and rate
+func (t *Tax) groupKey() string {
+	taxType, taxNumber := t.getTax()
+	if taxType == "amount" {
+		return t.Name + "|" + t.category() + "|amount"
+	}
+
+	return t.Name + "|" + t.category() + "|" + taxNumber.String()
+}
+
+// exemptionReason return the text explaining why no tax is charged
+func (t *Tax) exemptionReason(options *Options) string {
+	if len(t.ExemptionReason) > 0 {
+		return t.ExemptionReason
+	}
+
+	if t.category() == TaxCategoryReverseCharge {
+		return options.TextReverseCharge
+	}
+
+	return t.ExemptionReasonCode
+}
+
+// taxesKey identify a list of taxes
+func taxesKey(taxes []*Tax) string {
+	keys := make([]string, len(taxes))
+	for k, tax := range taxes {
+		keys[k] = tax.groupKey()
+		if tax.Compound {
+			keys[k] += "|compound"
+		}
+	}
+
+	return strings.Join(keys, ";")
+}
+
+// computeTaxes return the base and amount of each tax applied in order on base.
+// Compound taxes are computed on base plus the previous taxes, amount taxes are added as is.
+func computeTaxes(taxes []*Tax, base decimal.Decimal) ([]decimal.Decimal, []decimal.Decimal) {
+	bases := make([]decimal.Decimal, len(taxes))
+	amounts := make([]decimal.Decimal, len(taxes))
+	previous := decimal.NewFromFloat(0)
+
+	for k, tax := range taxes {
+		bases[k] = base
+		if tax.Compound {
+			bases[k] = base.Add(previous)
+		}
+
+		taxType, taxNumber := tax.getTax()
+		if taxType == "amount" {
+			amounts[k] = taxNumber
+		} else {
+			amounts[k] = bases[k].Mul(taxNumber.Div(decimal.NewFromFloat(100)))
+		}
+
+		previous = previous.Add(amounts[k])
+	}
+
+	return bases, amounts
+}
+
+// taxesDelta return the change of each tax base and amount when base changes by delta.
+// Amount taxes do not change.
+func taxesDelta(taxes []*Tax, delta decimal.Decimal) ([]decimal.Decimal, []decimal.Decimal) {
+	zeroBases, zeroAmounts := computeTaxes(taxes, decimal.NewFromFloat(0))
+	bases, amounts := computeTaxes(taxes, delta)
+	for k := range taxes {
+		bases[k] = bases[k].Sub(zeroBases[k])
+		amounts[k] = amounts[k].Sub(zeroAmounts[k])
+	}
+
+	return bases, amounts
+}
+
+// splitGross return the net amount included in a gross amount, with the base and amount of each tax,
+// rounded to precision. The last tax takes the rounding difference so that net and taxes add up to gross.
+func splitGross(taxes []*Tax, gross decimal.Decimal, precision int32) (decimal.Decimal, []decimal.Decimal, []decimal.Decimal) {
+	gross = gross.Round(precision)
+	if len(taxes) == 0 {
+		return gross, nil, nil
+	}
+
+	// Taxes are affine on the net amount: fixed amounts plus a rate
+	_, fixedAmounts := computeTaxes(taxes, decimal.NewFromFloat(0))
+	_, unitAmounts := computeTaxes(taxes, decimal.NewFromFloat(1))
+	fixed := decimal.Sum(decimal.NewFromFloat(0), fixedAmounts...)
+	rate := decimal.Sum(decimal.NewFromFloat(0), unitAmounts...).Sub(fixed)
+
+	net := gross.Sub(fixed).Div(rate.Add(decimal.NewFromFloat(1))).Round(precision)
+	bases, amounts := computeTaxes(taxes, net)
+
+	remaining := gross.Sub(net)
+	for k := range amounts {
+		if k == len(amounts)-1 {
+			amounts[k] = remaining
+		} else {
+			amounts[k] = amounts[k].Round(precision)
+			remaining = remaining.Sub(amounts[k])
+		}
+	}
+
+	return net, bases, amounts
+}
@@ -0,0 +1,161 @@
+package generator
+
+import (
+	"testing"
+)
+
+func newTestDocument() *Document {
+	doc, _ := New(Invoice, &Options{})
+	doc.SetRef("ref")
+	doc.SetCompany(&Contact{Name: "Company", Address: &Address{Address: "Street 1"}})
+	doc.SetCustomer(&Contact{Name: "Customer", Address: &Address{Address: "Street 2"}})
+
+	return doc
+}
+
+func TestTaxGroups(t *testing.T) {
+	doc := newTestDocument()
+	doc.AppendItem(&Item{Name: "Standard", UnitCost: "100", Quantity: "2", Tax: &Tax{Percent: "20"}})
+	doc.AppendItem(&Item{Name: "Standard", UnitCost: "50", Quantity: "1", Tax: &Tax{Percent: "20.0"}})
+	doc.AppendItem(&Item{Name: "Reduced", UnitCost: "10", Quantity: "1", Tax: &Tax{Percent: "10"}})
+	doc.AppendItem(&Item{Name: "Service", UnitCost: "300", Quantity: "1", Tax: &Tax{Category: TaxCategoryReverseCharge}})
+
+	groups := doc.taxGroups()
+	if len(groups) != 3 {
+		t.Fatalf("expected 3 tax groups, got %d", len(groups))
+	}
+
+	expected := []struct {
+		category string
+		base     string
+		amount   string
+	}{
+		{TaxCategoryStandard, "250", "50"},
+		{TaxCategoryStandard, "10", "1"},
+		{TaxCategoryReverseCharge, "300", "0"},
+	}
+	for i, e := range expected {
+		if groups[i].tax.category() != e.category || groups[i].base.String() != e.base || groups[i].amount.String() != e.amount {
+			t.Errorf("group %d: got %s %s %s, expected %s %s %s", i,
+				groups[i].tax.category(), groups[i].base, groups[i].amount,
+				e.category, e.base, e.amount,
+			)
+		}
+	}
+
+	if reason := groups[2].tax.exemptionReason(doc.Options); reason != doc.Options.TextReverseCharge {
+		t.Errorf("unexpected reverse charge reason %q", reason)
+	}
+
+	if _, err := doc.Build(); err != nil {
+		t.Errorf("unexpected build error: %s", err)
+	}
+}
+
+func TestValidateTaxCategory(t *testing.T) {
+	doc := newTestDocument()
+	doc.AppendItem(&Item{Name: "Exempt", UnitCost: "100", Quantity: "1", Tax: &Tax{Category: TaxCategoryExempt}})
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for exempt tax without reason")
+	}
+
+	doc.Items[0].Tax.ExemptionReasonCode = "VATEX-EU-132"
+	if err := doc.Validate(); err != nil {
+		t.Errorf("unexpected validation error: %s", err)
+	}
+
+	doc.Items[0].Tax.Percent = "20"
+	if err := doc.Validate(); err == nil {
+		t.Errorf("expected validation error for exempt tax with a rate")
+	}
+}
+
+func TestCompoundTaxes(t *testing.T) {
+	doc := newTestDocument()
+	doc.AppendItem(&Item{
+		Name:     "Quebec",
+		UnitCost: "100",
+		Quantity: "1",
+		Taxes: []*Tax{
+			{Name: "GST", Percent: "5"},
+			{Name: "QST", Percent: "7.5", Compound: true},
+		},
+	})
+	doc.AppendItem(&Item{
+		Name:     "Ontario",
+		UnitCost: "200",
+		Quantity: "1",
+		Taxes: []*Tax{
+			{Name: "GST", Percent: "5"},
+			{Name: "PST", Percent: "8"},
+		},
+	})
+
+	if total := doc.Items[0].totalWithTaxAndDiscount(doc); total.String() != "112.875" {
+		t.Errorf("expected compound total 112.875, got %s", total)
+	}
+
+	groups := doc.taxGroups()
+	if len(groups) != 3 {
+		t.Fatalf("expected 3 tax groups, got %d", len(groups))
+	}
+
+	expected := []struct {
+		title  string
+		base   string
+		amount string
+	}{
+		{"GST 5 %", "300", "15"},
+		{"QST 7.5 %", "105", "7.88"},
+		{"PST 8 %", "200", "16"},
+	}
+	for i, e := range expected {
+		if groups[i].title(doc.Options) != e.title || groups[i].base.String() != e.base || groups[i].amount.String() != e.amount {
+			t.Errorf("group %d: got %s %s %s, expected %s %s %s", i,
+				groups[i].title(doc.Options), groups[i].base, groups[i].amount,
+				e.title, e.base, e.amount,
+			)
+		}
+	}
+
+	if _, err := doc.Build(); err != nil {
+		t.Errorf("unexpected build error: %s", err)
+	}
+}
+
+func TestPricesIncludeTax(t *testing.T) {
+	doc := newTestDocument()
+	doc.SetPricesIncludeTax(true)
+	doc.SetDefaultTax(&Tax{Percent: "19"})
+	for i := 0; i < 3; i++ {
+		doc.AppendItem(&Item{Name: "Book", UnitCost: "9.99", Quantity: "1"})
+	}
+	doc.AppendItem(&Item{Name: "Pen", UnitCost: "1.19", Quantity: "3", Discount: &Discount{Percent: "10"}})
+
+	if _, err := doc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+
+	book := doc.Items[0]
+	if net := book.totalWithoutTaxAndWithDiscount(doc); net.String() != "8.39" {
+		t.Errorf("expected book net 8.39, got %s", net)
+	}
+	if tax := book.taxWithDiscount(doc); tax.String() != "1.6" {
+		t.Errorf("expected book tax 1.60, got %s", tax)
+	}
+
+	// Gross totals match the quoted prices: 3 × 9.99 + 3 × 1.19 - 10 %
+	total := doc.totalWithDiscount().Add(doc.totalTax())
+	if !total.Equal(decimalFromString("33.18")) {
+		t.Errorf("expected total with tax 33.18, got %s", total)
+	}
+
+	// Build does not change the items, the document flag is read with the prices
+	if book.PriceIncludesTax {
+		t.Errorf("expected the book price flag to be left unset")
+	}
+	doc.SetPricesIncludeTax(false)
+	if net := book.totalWithoutTaxAndWithDiscount(doc); net.String() != "9.99" {
+		t.Errorf("expected book net 9.99 once prices exclude tax, got %s", net)
+	}
+}
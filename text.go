@@ -0,0 +1,254 @@
+package generator
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/signintech/gopdf"
+)
+
+// textRun define a part of a text drawn with a single font family
+type textRun struct {
+	family string
+	text   string
+}
+
+// fontFamilies return the document font family followed by the fallback ones, in order
+func (doc *Document) fontFamilies() []string {
+	families := []string{doc.Options.Font.Family}
+	for _, font := range doc.Options.FallbackFonts {
+		families = append(families, font.Family)
+	}
+
+	return families
+}
+
+// hasGlyph return true when the font family, in the current style, has a glyph for the rune
+func (doc *Document) hasGlyph(family string, r rune) bool {
+	key := glyphKey{family: family, style: doc.fontStyle, r: r}
+	if found, ok := doc.glyphs[key]; ok {
+		return found
+	}
+
+	_ = doc.pdf.SetFont(family, doc.fontStyle, doc.fontSize)
+	found, _ := doc.pdf.IsCurrFontContainGlyph(r)
+	if doc.glyphs == nil {
+		doc.glyphs = map[glyphKey]bool{}
+	}
+	doc.glyphs[key] = found
+
+	return found
+}
+
+// glyphKey identify a rune in a font family and style
+type glyphKey struct {
+	family string
+	style  string
+	r      rune
+}
+
+// textRuns split a text in runs drawn with the first font family having their glyphs.
+// Spaces stay in the current run, runes without any glyph use the document font.
+func (doc *Document) textRuns(text string) []*textRun {
+	families := doc.fontFamilies()
+	if len(families) == 1 {
+		return []*textRun{{family: families[0], text: text}}
+	}
+	defer doc.setFont(doc.fontStyle, doc.fontSize)
+
+	var runs []*textRun
+	for _, r := range text {
+		family := families[0]
+		if unicode.IsSpace(r) && len(runs) > 0 {
+			family = runs[len(runs)-1].family
+		} else {
+			for _, f := range families {
+				if doc.hasGlyph(f, r) {
+					family = f
+					break
+				}
+			}
+		}
+
+		if len(runs) > 0 && runs[len(runs)-1].family == family {
+			runs[len(runs)-1].text += string(r)
+		} else {
+			runs = append(runs, &textRun{family: family, text: string(r)})
+		}
+	}
+
+	return runs
+}
+
+// singleFont return true when runs are drawn with the document font only
+func (doc *Document) singleFont(runs []*textRun) bool {
+	return len(runs) == 0 || len(runs) == 1 && runs[0].family == doc.Options.Font.Family
+}
+
+// runsWidths return the width of each run drawn with its font family
+func (doc *Document) runsWidths(runs []*textRun) []float64 {
+	defer doc.setFont(doc.fontStyle, doc.fontSize)
+
+	widths := make([]float64, len(runs))
+	for k, run := range runs {
+		_ = doc.pdf.SetFont(run.family, doc.fontStyle, doc.fontSize)
+		widths[k], _ = doc.pdf.MeasureTextWidth(run.text)
+	}
+
+	return widths
+}
+
+// measureText return the width of a text drawn with fallback fonts
+func (doc *Document) measureText(text string) float64 {
+	width := 0.0
+	for _, w := range doc.runsWidths(doc.textRuns(text)) {
+		width += w
+	}
+
+	return width
+}
+
+// cell draws a text like gopdf Cell, with fallback fonts
+func (doc *Document) cell(rect *gopdf.Rect, text string) {
+	doc.cellWithOption(rect, text, gopdf.CellOption{Align: gopdf.Left | gopdf.Top, Float: gopdf.Right})
+}
+
+// cellWithOption draws a text like gopdf CellWithOption, with fallback fonts.
+// Text is reordered for display, the cell is mirrored in right to left documents.
+func (doc *Document) cellWithOption(rect *gopdf.Rect, text string, opt gopdf.CellOption) {
+	text = visualOrder(text, doc.isRTL())
+	if !doc.isRTL() {
+		doc.drawCell(rect, text, opt)
+		return
+	}
+
+	x := doc.pdf.GetX()
+	if rect == nil {
+		rect = &gopdf.Rect{W: doc.measureText(text)}
+		opt.Align = gopdf.Left | gopdf.Top
+	}
+
+	doc.pdf.SetX(doc.drawX(x, rect.W))
+	doc.drawCell(rect, text, gopdf.CellOption{Align: mirrorAlign(opt.Align), Border: opt.Border, Float: opt.Float})
+	doc.pdf.SetX(x + rect.W)
+}
+
+// drawCell draws a text in display order, splitting it in runs of fallback fonts
+func (doc *Document) drawCell(rect *gopdf.Rect, text string, opt gopdf.CellOption) {
+	runs := doc.textRuns(text)
+	if doc.singleFont(runs) {
+		if rect == nil {
+			_ = doc.pdf.Cell(nil, text)
+		} else {
+			_ = doc.pdf.CellWithOption(rect, text, opt)
+		}
+		return
+	}
+	defer doc.setFont(doc.fontStyle, doc.fontSize)
+
+	x, y := doc.pdf.GetX(), doc.pdf.GetY()
+	widths := doc.runsWidths(runs)
+	total := 0.0
+	for _, w := range widths {
+		total += w
+	}
+
+	// Runs are drawn one after the other, the whole text is aligned in the cell
+	width, height, startX := total, 0.0, x
+	if rect != nil {
+		width, height = rect.W, rect.H
+		if opt.Align&gopdf.Right == gopdf.Right {
+			startX = x + rect.W - total
+		} else if opt.Align&gopdf.Center == gopdf.Center {
+			startX = x + (rect.W-total)/2
+		}
+	}
+
+	vertical := opt.Align &^ (gopdf.Left | gopdf.Right | gopdf.Center)
+	if vertical == 0 {
+		vertical = gopdf.Top
+	}
+
+	for k, run := range runs {
+		_ = doc.pdf.SetFont(run.family, doc.fontStyle, doc.fontSize)
+		doc.pdf.SetX(startX)
+		doc.pdf.SetY(y)
+		_ = doc.pdf.CellWithOption(&gopdf.Rect{W: widths[k], H: height}, run.text, gopdf.CellOption{Align: gopdf.Left | vertical})
+		startX += widths[k]
+	}
+
+	doc.pdf.SetX(x + width)
+	doc.pdf.SetY(y)
+}
+
+// multiCell draws a text wrapped in a rect between words like wrapText, with fallback fonts.
+// Lines not fitting in the rect height are not drawn, they are reordered for display and right aligned in right to left documents.
+func (doc *Document) multiCell(rect *gopdf.Rect, text string) {
+	x := doc.pdf.GetX()
+	lineHeight := doc.Options.Font.lineHeight(doc.fontSize)
+
+	for _, line := range doc.multiCellLines(rect, text) {
+		doc.cell(&gopdf.Rect{W: rect.W, H: lineHeight}, line)
+		doc.pdf.Br(lineHeight)
+		doc.pdf.SetX(x)
+	}
+}
+
+// multiCellHeight return the height of a text drawn by multiCell in rect with the current font
+func (doc *Document) multiCellHeight(rect *gopdf.Rect, text string) float64 {
+	return float64(len(doc.multiCellLines(rect, text))) * doc.Options.Font.lineHeight(doc.fontSize)
+}
+
+// multiCellLines return the lines of a text drawn by multiCell, as many as fit in the rect height
+func (doc *Document) multiCellLines(rect *gopdf.Rect, text string) []string {
+	if len(text) == 0 {
+		return nil
+	}
+
+	lines := doc.wrapText(text, rect.W)
+	lineHeight := doc.Options.Font.lineHeight(doc.fontSize)
+	for k := range lines {
+		if float64(k+1)*lineHeight > rect.H {
+			return lines[:k]
+		}
+	}
+
+	return lines
+}
+
+// wrapText split a text in lines fitting in width, breaking between words when possible
+func (doc *Document) wrapText(text string, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if len(line) > 0 {
+				candidate = line + " " + word
+			}
+
+			if doc.measureText(candidate) <= width {
+				line = candidate
+				continue
+			}
+
+			if len(line) > 0 {
+				lines = append(lines, line)
+			}
+
+			// Words wider than the line are broken
+			line = ""
+			for _, r := range word {
+				if len(line) > 0 && doc.measureText(line+string(r)) > width {
+					lines = append(lines, line)
+					line = ""
+				}
+				line += string(r)
+			}
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/signintech/gopdf"
+)
+
+// fallbackFontPath is a DejaVu Sans subset with the latin, hebrew, arabic and arrows glyphs used by tests
+const fallbackFontPath = "testdata/DejaVuSans.ttf"
+
+func newFallbackDocument(t *testing.T) *Document {
+	doc, err := New(Invoice, &Options{FallbackFonts: []*Font{{Family: "DejaVu", RegularPath: fallbackFontPath}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return doc
+}
+
+func TestTextRuns(t *testing.T) {
+	doc := newFallbackDocument(t)
+	doc.setFont("", 10)
+
+	expected := []struct {
+		glyph  rune
+		family string
+	}{
+		{'A', "Ubuntu"},
+		{'Ж', "Ubuntu"},
+		{'Ω', "Ubuntu"},
+		{'م', "DejaVu"},
+		{'א', "DejaVu"},
+		{'→', "DejaVu"},
+		{'東', "Ubuntu"}, // No glyph in any font
+	}
+	for _, e := range expected {
+		runs := doc.textRuns(string(e.glyph))
+		if len(runs) != 1 || runs[0].family != e.family {
+			t.Errorf("%c: expected %s, got %+v", e.glyph, e.family, runs[0])
+		}
+	}
+
+	runs := doc.textRuns("Café مرحبا → Ωmega")
+	expectedRuns := []textRun{
+		{family: "Ubuntu", text: "Café "},
+		{family: "DejaVu", text: "مرحبا → "},
+		{family: "Ubuntu", text: "Ωmega"},
+	}
+	if len(runs) != len(expectedRuns) {
+		t.Fatalf("expected %d runs, got %d", len(expectedRuns), len(runs))
+	}
+	for k, run := range runs {
+		if *run != expectedRuns[k] {
+			t.Errorf("run %d: expected %+v, got %+v", k, expectedRuns[k], *run)
+		}
+	}
+
+	// Runs are measured with their own font
+	if width := doc.measureText("Café مرحبا"); width <= doc.measureText("Café ") {
+		t.Errorf("unexpected text width %v", width)
+	}
+}
+
+func TestFallbackMultiCell(t *testing.T) {
+	doc := newFallbackDocument(t)
+	doc.pdf.AddPage()
+	doc.setFont("", 10)
+
+	doc.pdf.SetY(100)
+	doc.multiCell(&gopdf.Rect{W: 60, H: 100}, "مرحبا مرحبا مرحبا مرحبا")
+	lines := (doc.pdf.GetY() - 100) / doc.Options.Font.lineHeight(10)
+	if lines < 2 {
+		t.Errorf("expected text to wrap, got %v lines", lines)
+	}
+}
+
+func TestFallbackBuild(t *testing.T) {
+	doc := newFallbackDocument(t)
+	doc.SetRef("ref")
+	doc.SetNotes("שלום → مرحبا")
+	doc.SetCompany(&Contact{Name: "شركة", Address: &Address{Address: "שדרות 1"}})
+	doc.SetCustomer(&Contact{Name: "Клиент →", Address: &Address{Address: "Street 2"}})
+	doc.AppendItem(&Item{Name: "خدمة", Description: "תיאור", UnitCost: "10", Quantity: "1"})
+
+	if _, err := doc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+}
+
+func TestMultiCellWordWrap(t *testing.T) {
+	doc := newTestDocument()
+	doc.pdf.AddPage()
+	doc.setFont("", itemFontSize)
+
+	// Lines break between words, as item attributes and headings do
+	width := doc.measureText("Lorem ipsum") + 1
+	rect := &gopdf.Rect{W: width, H: 100}
+	if lines := doc.multiCellLines(rect, "Lorem ipsum dolor"); !reflect.DeepEqual(lines, []string{"Lorem ipsum", "dolor"}) {
+		t.Errorf("expected lines broken between words, got %q", lines)
+	}
+
+	box := &itemColumnBox{column: &ItemColumn{Key: ItemColumnAttribute}, w: width + itemTitleMargin}
+	text := "Lorem ipsum dolor sit amet, consectetur adipiscing elit"
+	if height := doc.multiCellHeight(rect, text); height != doc.itemMultiCellHeight(box, text) {
+		t.Errorf("expected names and attributes to wrap alike, got %v and %v", height, doc.itemMultiCellHeight(box, text))
+	}
+
+	// Lines below the rect are not drawn
+	if lines := doc.multiCellLines(&gopdf.Rect{W: width, H: doc.Options.Font.lineHeight(itemFontSize)}, text); len(lines) != 1 {
+		t.Errorf("expected a single line in a line high rect, got %d", len(lines))
+	}
+}
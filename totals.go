@@ -0,0 +1,292 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// taxGroup define the tax base and tax amount of items sharing a tax category and rate
+type taxGroup struct {
+	tax    *Tax // First tax of the group, gives its category, rate and exemption reason
+	base   decimal.Decimal
+	amount decimal.Decimal
+}
+
+// withholdingGroup define the base and withheld amount of items sharing a withholding name and rate
+type withholdingGroup struct {
+	withholding *Withholding
+	base        decimal.Decimal
+	amount      decimal.Decimal
+}
+
+// title return the group title in totals, ex "IRPF -15 %"
+func (g *withholdingGroup) title(options *Options) string {
+	title := g.withholding.Name
+	if len(title) == 0 {
+		title = options.TextTotalWithholding
+	}
+
+	withholdingType, withholdingNumber := g.withholding.getWithholding()
+	if withholdingType == "amount" {
+		return title
+	}
+
+	return fmt.Sprintf("%s -%s %%", title, withholdingNumber)
+}
+
+// title return the group title in totals, ex "TAX 20 %", "GST 5 %" or "REVERSE CHARGE"
+func (g *taxGroup) title(options *Options) string {
+	if title, ok := options.TextTaxCategories[g.tax.category()]; ok {
+		return title
+	}
+
+	title := g.tax.Name
+	if len(title) == 0 {
+		title = options.TextTotalTax
+	}
+
+	taxType, taxNumber := g.tax.getTax()
+	if taxType == "amount" {
+		return title
+	}
+
+	return fmt.Sprintf("%s %s %%", title, taxNumber)
+}
+
+// totalWithoutTax return the sum of items totals without tax and with their discount
+func (doc *Document) totalWithoutTax() decimal.Decimal {
+	total := decimal.NewFromFloat(0)
+	for _, item := range doc.billedItems() {
+		total = total.Add(item.totalWithoutTaxAndWithDiscount(doc))
+	}
+
+	return total
+}
+
+// totalDiscount return the document discount amount
+func (doc *Document) totalDiscount() decimal.Decimal {
+	if doc.Discount == nil {
+		return decimal.NewFromFloat(0)
+	}
+
+	discountType, discountNumber := doc.Discount.getDiscount()
+	if discountType == "amount" {
+		return discountNumber
+	}
+
+	// Percent
+	return doc.totalWithoutTax().Mul(discountNumber.Div(decimal.NewFromFloat(100)))
+}
+
+// totalWithDiscount return the total without tax with the document discount applied
+func (doc *Document) totalWithDiscount() decimal.Decimal {
+	return doc.totalWithoutTax().Sub(doc.totalDiscount())
+}
+
+// totalCharges return the sum of document charges minus allowances
+func (doc *Document) totalCharges() decimal.Decimal {
+	total := decimal.NewFromFloat(0)
+	for _, charge := range doc.Charges {
+		total = total.Add(charge.amount(doc.totalWithoutTax()))
+	}
+
+	return total
+}
+
+// totalNet return the total without tax, with document discount, charges and allowances
+func (doc *Document) totalNet() decimal.Decimal {
+	return doc.totalWithDiscount().Add(doc.totalCharges())
+}
+
+// discountPercent return the document discount as percent of items totals
+func (doc *Document) discountPercent() decimal.Decimal {
+	total := doc.totalWithoutTax()
+	if doc.Discount == nil || total.IsZero() {
+		return decimal.NewFromFloat(0)
+	}
+
+	return doc.totalDiscount().Mul(decimal.NewFromFloat(100)).Div(total)
+}
+
+// itemTotalWithDocDiscount return the item total without tax, with its discount and the document discount percent
+func (doc *Document) itemTotalWithDocDiscount(item *Item, discountPercent decimal.Decimal) decimal.Decimal {
+	itemTotal := item.totalWithoutTaxAndWithDiscount(doc)
+	toSub := discountPercent.Mul(itemTotal).Div(decimal.NewFromFloat(100))
+
+	return itemTotal.Sub(toSub)
+}
+
+// taxBase define the net base of items sharing the same taxes
+type taxBase struct {
+	taxes []*Tax
+	base  decimal.Decimal
+}
+
+// taxBases return the items net bases grouped by their taxes, in order of first appearance
+func (doc *Document) taxBases() []*taxBase {
+	var bases []*taxBase
+	byKey := map[string]*taxBase{}
+
+	for _, item := range doc.billedItems() {
+		key := taxesKey(item.taxes())
+		base, ok := byKey[key]
+		if !ok {
+			base = &taxBase{taxes: item.taxes()}
+			byKey[key] = base
+			bases = append(bases, base)
+		}
+
+		base.base = base.base.Add(item.totalWithoutTaxAndWithDiscount(doc))
+	}
+
+	return bases
+}
+
+// apportion split a document level amount between tax bases in proportion to their net base.
+// Shares are rounded to the currency precision, the last one takes the rounding difference.
+func (doc *Document) apportion(amount decimal.Decimal, bases []*taxBase) []decimal.Decimal {
+	shares := make([]decimal.Decimal, len(bases))
+	total := decimal.NewFromFloat(0)
+	for _, base := range bases {
+		total = total.Add(base.base)
+	}
+
+	if total.IsZero() {
+		return shares
+	}
+
+	remaining := amount
+	for k, base := range bases {
+		if k == len(bases)-1 {
+			shares[k] = remaining
+			break
+		}
+
+		shares[k] = amount.Mul(base.base).Div(total).Round(int32(doc.Options.CurrencyPrecision))
+		remaining = remaining.Sub(shares[k])
+	}
+
+	return shares
+}
+
+// taxGroups return the items taxes grouped by name, category and rate, in order of first appearance.
+// Document discount is apportioned between items sharing the same taxes, as an EN 16931 allowance,
+// document charges and allowances are added with their own tax.
+func (doc *Document) taxGroups() []*taxGroup {
+	var groups []*taxGroup
+	byKey := map[string]*taxGroup{}
+
+	addTaxes := func(taxes []*Tax, bases []decimal.Decimal, amounts []decimal.Decimal) {
+		for k, tax := range taxes {
+			group, ok := byKey[tax.groupKey()]
+			if !ok {
+				group = &taxGroup{tax: tax}
+				byKey[tax.groupKey()] = group
+				groups = append(groups, group)
+			}
+
+			group.base = group.base.Add(bases[k])
+			group.amount = group.amount.Add(amounts[k])
+		}
+	}
+
+	// Items taxes
+	for _, item := range doc.billedItems() {
+		bases, amounts := item.taxAmounts(doc)
+		addTaxes(item.taxes(), bases, amounts)
+	}
+
+	// Document discount, amount taxes are not affected
+	if doc.Discount != nil {
+		taxBases := doc.taxBases()
+		shares := doc.apportion(doc.totalDiscount(), taxBases)
+		for k, taxBase := range taxBases {
+			bases, amounts := taxesDelta(taxBase.taxes, shares[k].Neg())
+			addTaxes(taxBase.taxes, bases, amounts)
+		}
+	}
+
+	// Document charges and allowances, with their own tax
+	for _, charge := range doc.Charges {
+		taxes := charge.taxes(doc.DefaultTax)
+		bases, amounts := computeTaxes(taxes, charge.amount(doc.totalWithoutTax()))
+		addTaxes(taxes, bases, amounts)
+	}
+
+	// Each group tax is rounded, the total tax is the sum of the printed amounts
+	for _, group := range groups {
+		group.amount = group.amount.Round(int32(doc.Options.CurrencyPrecision))
+	}
+
+	return groups
+}
+
+// totalTax return the sum of all taxes
+func (doc *Document) totalTax() decimal.Decimal {
+	total := decimal.NewFromFloat(0)
+	for _, group := range doc.taxGroups() {
+		total = total.Add(group.amount)
+	}
+
+	return total
+}
+
+// withholdingGroups return the items withholdings grouped by name and rate, in order of first appearance.
+// Items without their own withholding use the document one, its fixed amount is only withheld once.
+func (doc *Document) withholdingGroups() []*withholdingGroup {
+	var groups []*withholdingGroup
+	byKey := map[string]*withholdingGroup{}
+	documentAmountWithheld := false
+	discountPercent := doc.discountPercent()
+
+	for _, item := range doc.billedItems() {
+		withholding := item.Withholding
+		if withholding == nil {
+			withholding = doc.Withholding
+		}
+		if withholding == nil {
+			continue
+		}
+
+		// Withholding is computed on the net base, tax excluded
+		itemTotalDiscounted := doc.itemTotalWithDocDiscount(item, discountPercent)
+		withholdingType, withholdingNumber := withholding.getWithholding()
+		itemWithholding := withholdingNumber
+		if withholdingType == "percent" {
+			itemWithholding = withholdingNumber.Mul(itemTotalDiscounted).Div(decimal.NewFromFloat(100))
+		} else if withholding == doc.Withholding {
+			if documentAmountWithheld {
+				itemWithholding = decimal.NewFromFloat(0)
+			}
+			documentAmountWithheld = true
+		}
+
+		group, ok := byKey[withholding.groupKey()]
+		if !ok {
+			group = &withholdingGroup{withholding: withholding}
+			byKey[withholding.groupKey()] = group
+			groups = append(groups, group)
+		}
+
+		group.base = group.base.Add(itemTotalDiscounted)
+		group.amount = group.amount.Add(itemWithholding)
+	}
+
+	return groups
+}
+
+// totalWithholding return the sum of all withholdings
+func (doc *Document) totalWithholding() decimal.Decimal {
+	total := decimal.NewFromFloat(0)
+	for _, group := range doc.withholdingGroups() {
+		total = total.Add(group.amount)
+	}
+
+	return total
+}
+
+// totalPayable return the total with tax minus withholdings
+func (doc *Document) totalPayable() decimal.Decimal {
+	return doc.totalNet().Add(doc.totalTax()).Sub(doc.totalWithholding())
+}
@@ -0,0 +1,161 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestWithholding(t *testing.T) {
+	doc := newTestDocument()
+	doc.SetDefaultTax(&Tax{Percent: "21"})
+	doc.SetWithholding(&Withholding{Name: "IRPF", Percent: "15"})
+	doc.AppendItem(&Item{Name: "Consulting", UnitCost: "100", Quantity: "10"})
+	doc.AppendItem(&Item{Name: "Travel", UnitCost: "200", Quantity: "1", Withholding: &Withholding{Name: "IRPF", Percent: "7"}})
+
+	if _, err := doc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+
+	groups := doc.withholdingGroups()
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 withholding groups, got %d", len(groups))
+	}
+	if !groups[0].amount.Equal(decimalFromString("150")) || !groups[1].amount.Equal(decimalFromString("14")) {
+		t.Errorf("unexpected withholdings %s and %s", groups[0].amount, groups[1].amount)
+	}
+
+	// VAT base is not reduced by withholding: 1200 + 252 - 164
+	if tax := doc.totalTax(); !tax.Equal(decimalFromString("252")) {
+		t.Errorf("expected tax 252, got %s", tax)
+	}
+	if payable := doc.totalPayable(); !payable.Equal(decimalFromString("1288")) {
+		t.Errorf("expected payable 1288, got %s", payable)
+	}
+}
+
+func TestWithholdingAmount(t *testing.T) {
+	doc := newTestDocument()
+	doc.SetWithholding(&Withholding{Name: "IRPF", Amount: "10"})
+	doc.AppendItem(&Item{Name: "Consulting", UnitCost: "100", Quantity: "1"})
+	doc.AppendItem(&Item{Name: "Design", UnitCost: "50", Quantity: "1"})
+	doc.AppendItem(&Item{Name: "Travel", UnitCost: "20", Quantity: "1"})
+
+	if withholding := doc.totalWithholding(); !withholding.Equal(decimalFromString("10")) {
+		t.Errorf("expected the document amount to be withheld once, got %s", withholding)
+	}
+	if groups := doc.withholdingGroups(); len(groups) != 1 || !groups[0].base.Equal(decimalFromString("170")) {
+		t.Errorf("expected a single withholding group on base 170")
+	}
+
+	// Items own amounts are withheld for each item
+	doc.AppendItem(&Item{Name: "Support", UnitCost: "30", Quantity: "1", Withholding: &Withholding{Name: "IRPF", Amount: "5"}})
+	doc.AppendItem(&Item{Name: "Hosting", UnitCost: "30", Quantity: "1", Withholding: &Withholding{Name: "IRPF", Amount: "5"}})
+	if withholding := doc.totalWithholding(); !withholding.Equal(decimalFromString("20")) {
+		t.Errorf("expected withholding 20, got %s", withholding)
+	}
+}
+
+func decimalFromString(value string) decimal.Decimal {
+	d, _ := decimal.NewFromString(value)
+	return d
+}
+
+func TestDocumentDiscountApportionment(t *testing.T) {
+	cases := []struct {
+		name     string
+		prices   []string
+		discount *Discount
+		bases    []string
+		amounts  []string
+		totalTax string
+	}{
+		{
+			name:     "amount",
+			prices:   []string{"100", "50", "30"},
+			discount: &Discount{Amount: "30"},
+			bases:    []string{"83.33", "41.67", "25"},
+			amounts:  []string{"16.67", "4.17", "5"},
+			totalTax: "25.84",
+		},
+		{
+			name:     "percent",
+			prices:   []string{"100", "50", "30"},
+			discount: &Discount{Percent: "10"},
+			bases:    []string{"90", "45", "27"},
+			amounts:  []string{"18", "4.5", "5"},
+			totalTax: "27.5",
+		},
+		{
+			name:     "rounding",
+			prices:   []string{"100", "100", "100"},
+			discount: &Discount{Amount: "10"},
+			bases:    []string{"96.67", "96.67", "96.66"},
+			amounts:  []string{"19.33", "9.67", "5"},
+			totalTax: "34",
+		},
+	}
+
+	for _, c := range cases {
+		// Mixed rates: 20 %, 10 % and a fixed amount tax not affected by discount
+		doc := newTestDocument()
+		doc.AppendItem(&Item{Name: "Standard", UnitCost: c.prices[0], Quantity: "1", Tax: &Tax{Percent: "20"}})
+		doc.AppendItem(&Item{Name: "Reduced", UnitCost: c.prices[1], Quantity: "1", Tax: &Tax{Percent: "10"}})
+		doc.AppendItem(&Item{Name: "Fixed", UnitCost: c.prices[2], Quantity: "1", Tax: &Tax{Amount: "5"}})
+		doc.SetDiscount(c.discount)
+
+		groups := doc.taxGroups()
+		if len(groups) != len(c.amounts) {
+			t.Fatalf("%s: expected %d tax groups, got %d", c.name, len(c.amounts), len(groups))
+		}
+
+		for i, group := range groups {
+			if !group.base.Equal(decimalFromString(c.bases[i])) || !group.amount.Equal(decimalFromString(c.amounts[i])) {
+				t.Errorf("%s: group %d got base %s tax %s, expected base %s tax %s",
+					c.name, i, group.base, group.amount, c.bases[i], c.amounts[i],
+				)
+			}
+		}
+
+		if tax := doc.totalTax(); !tax.Equal(decimalFromString(c.totalTax)) {
+			t.Errorf("%s: expected total tax %s, got %s", c.name, c.totalTax, tax)
+		}
+	}
+}
+
+func TestCharges(t *testing.T) {
+	doc := newTestDocument()
+	doc.SetDefaultTax(&Tax{Percent: "20"})
+	doc.AppendItem(&Item{Name: "Book", UnitCost: "100", Quantity: "2", Tax: &Tax{Percent: "5.5"}})
+	doc.AppendCharge(&Charge{Reason: "Shipping", Amount: "10"})
+	doc.AppendCharge(&Charge{Reason: "Card surcharge", Percent: "2", Tax: &Tax{Category: TaxCategoryExempt, ExemptionReason: "Financial service"}})
+	doc.AppendCharge(&Charge{Reason: "Loyalty", Amount: "5", Allowance: true, Tax: &Tax{Percent: "5.5"}})
+
+	if _, err := doc.Build(); err != nil {
+		t.Fatalf("unexpected build error: %s", err)
+	}
+
+	// 200 + 10 + 4 - 5
+	if net := doc.totalNet(); !net.Equal(decimalFromString("209")) {
+		t.Errorf("expected net total 209, got %s", net)
+	}
+
+	groups := doc.taxGroups()
+	if len(groups) != 3 {
+		t.Fatalf("expected 3 tax groups, got %d", len(groups))
+	}
+
+	expected := []struct {
+		base   string
+		amount string
+	}{
+		{"195", "10.73"},
+		{"10", "2"},
+		{"4", "0"},
+	}
+	for i, e := range expected {
+		if !groups[i].base.Equal(decimalFromString(e.base)) || !groups[i].amount.Equal(decimalFromString(e.amount)) {
+			t.Errorf("group %d got base %s tax %s, expected base %s tax %s", i, groups[i].base, groups[i].amount, e.base, e.amount)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package generator
+
+import (
+	"regexp"
+)
+
+// unitCodeRegexp match the format of UN/ECE Recommendation 20 unit codes
+var unitCodeRegexp = regexp.MustCompile(`^[0-9A-Z]{2,3}$`)
+
+// isValidUnitCode check the format of a UN/ECE Recommendation 20 unit code (HUR, KGM, MTK...)
+func isValidUnitCode(code string) bool {
+	return unitCodeRegexp.MatchString(code)
+}
+
+// unitLabel return the display label of the item unit, the code itself when no label is set
+func (i *Item) unitLabel(options *Options) string {
+	if label, ok := options.TextUnits[i.Unit]; ok {
+		return label
+	}
+
+	return i.Unit
+}
+
+// quantityWithUnit return the item quantity followed by its unit label ex "2 h"
+func (i *Item) quantityWithUnit(options *Options) string {
+	if label := i.unitLabel(options); len(label) > 0 {
+		return i.quantity().String() + " " + label
+	}
+
+	return i.quantity().String()
+}
@@ -1,11 +1,167 @@
 package generator
 
 import (
+	"time"
+
+	"github.com/shopspring/decimal"
 	"gopkg.in/go-playground/validator.v9"
 )
 
 // Validate document fields
 func (d *Document) Validate() error {
 	validate := validator.New()
+
+	if err := validate.RegisterValidation("iban", func(fl validator.FieldLevel) bool {
+		return isValidIBAN(fl.Field().String())
+	}); err != nil {
+		return err
+	}
+
+	if err := validate.RegisterValidation("bic", func(fl validator.FieldLevel) bool {
+		return isValidBIC(fl.Field().String())
+	}); err != nil {
+		return err
+	}
+
+	if err := validate.RegisterValidation("vat", func(fl validator.FieldLevel) bool {
+		return isValidVAT(fl.Field().String())
+	}); err != nil {
+		return err
+	}
+
+	if err := validate.RegisterValidation("unit", func(fl validator.FieldLevel) bool {
+		return isValidUnitCode(fl.Field().String())
+	}); err != nil {
+		return err
+	}
+
+	validate.RegisterStructValidation(func(sl validator.StructLevel) {
+		address := sl.Current().Interface().(Address)
+		if len(address.BusinessID) > 0 && !isValidCompanyID(address.countryCode(), address.BusinessID) {
+			sl.ReportError(address.BusinessID, "BusinessID", "BusinessID", "company_id", address.countryCode())
+		}
+	}, Address{})
+
+	validate.RegisterStructValidation(func(sl validator.StructLevel) {
+		tax := sl.Current().Interface().(Tax)
+		if tax.category() == TaxCategoryStandard {
+			return
+		}
+
+		// Only standard rated taxes can be charged
+		if _, taxNumber := tax.getTax(); !taxNumber.IsZero() {
+			sl.ReportError(tax.Percent+tax.Amount, "Percent", "Percent", "zero_rate", tax.category())
+		}
+
+		// Exempt and out of scope taxes must explain why
+		if (tax.category() == TaxCategoryExempt || tax.category() == TaxCategoryOutOfScope) &&
+			len(tax.ExemptionReason) == 0 && len(tax.ExemptionReasonCode) == 0 {
+			sl.ReportError(tax.ExemptionReason, "ExemptionReason", "ExemptionReason", "required", tax.category())
+		}
+	}, Tax{})
+
+	validate.RegisterStructValidation(func(sl validator.StructLevel) {
+		item := sl.Current().Interface().(Item)
+
+		// Tiers must be in ascending order, only the last one is unbounded so no quantity is left unbilled
+		from := decimal.NewFromFloat(0)
+		for k, tier := range item.Tiers {
+			upTo, bounded := tier.upTo()
+			if !bounded && k < len(item.Tiers)-1 || bounded && !upTo.GreaterThan(from) {
+				sl.ReportError(tier.UpTo, "UpTo", "UpTo", "tiers_order", "")
+				return
+			}
+			if bounded && k == len(item.Tiers)-1 {
+				sl.ReportError(tier.UpTo, "UpTo", "UpTo", "tiers_unbounded", "")
+				return
+			}
+			from = upTo
+		}
+
+		if len(item.StandardID) > 0 && item.isGTIN() && !isValidGTIN(item.StandardID) {
+			sl.ReportError(item.StandardID, "StandardID", "StandardID", "gtin", "")
+		}
+
+		// Bundle children are drawn one level deep and taxed with their bundle
+		for _, child := range item.Children {
+			if len(child.Children) > 0 {
+				sl.ReportError(child.Children, "Children", "Children", "bundle_depth", "")
+			}
+			if child.Tax != nil || len(child.Taxes) > 0 || child.PriceIncludesTax || child.Withholding != nil {
+				sl.ReportError(child.Tax, "Tax", "Tax", "bundle_tax", "")
+			}
+		}
+	}, Item{})
+
+	validate.RegisterStructValidation(func(sl validator.StructLevel) {
+		column := sl.Current().Interface().(ItemColumn)
+		if column.Key == ItemColumnAttribute && len(column.Attribute) == 0 {
+			sl.ReportError(column.Attribute, "Attribute", "Attribute", "required", column.Key)
+		}
+	}, ItemColumn{})
+
+	validate.RegisterStructValidation(func(sl validator.StructLevel) {
+		doc := sl.Current().Interface().(Document)
+
+		// Cash discounts deadlines are computed from the document date
+		if len(doc.CashDiscounts) > 0 && len(doc.Date) > 0 && doc.Options != nil {
+			if _, err := time.Parse(doc.Options.DateFormat, doc.Date); err != nil {
+				sl.ReportError(doc.Date, "Date", "Date", "date_format", doc.Options.DateFormat)
+			}
+		}
+
+		// Alternatives must reference another item
+		for _, item := range doc.Items {
+			if len(item.AlternativeTo) == 0 {
+				continue
+			}
+			if alternative := doc.itemByID(item.AlternativeTo); alternative == nil || alternative == item {
+				sl.ReportError(item.AlternativeTo, "AlternativeTo", "AlternativeTo", "item_id", "")
+			}
+		}
+
+		// Reminders list open invoices and compute interests from their due date to the document date
+		if doc.Type == Reminder {
+			if doc.Dunning == nil {
+				sl.ReportError(doc.Dunning, "Dunning", "Dunning", "required", "")
+				return
+			}
+
+			if _, err := time.Parse(doc.Options.DateFormat, doc.Date); err != nil {
+				sl.ReportError(doc.Date, "Date", "Date", "date_format", doc.Options.DateFormat)
+			}
+
+			for _, invoice := range doc.Dunning.Invoices {
+				if _, err := time.Parse(doc.Options.DateFormat, invoice.DueDate); err != nil {
+					sl.ReportError(invoice.DueDate, "DueDate", "DueDate", "date_format", doc.Options.DateFormat)
+				}
+			}
+		}
+
+		// Statements age ledger entries from their due date to the document date
+		if doc.Type == Statement {
+			if doc.Statement == nil {
+				sl.ReportError(doc.Statement, "Statement", "Statement", "required", "")
+				return
+			}
+
+			if _, err := time.Parse(doc.Options.DateFormat, doc.Date); err != nil {
+				sl.ReportError(doc.Date, "Date", "Date", "date_format", doc.Options.DateFormat)
+			}
+
+			if doc.Statement.openingBalance().IsPositive() {
+				if _, err := time.Parse(doc.Options.DateFormat, doc.Statement.OpeningBalanceDueDate); err != nil {
+					sl.ReportError(doc.Statement.OpeningBalanceDueDate, "OpeningBalanceDueDate", "OpeningBalanceDueDate", "date_format", doc.Options.DateFormat)
+				}
+			}
+
+			for _, entry := range doc.Statement.Entries {
+				if _, err := time.Parse(doc.Options.DateFormat, entry.dueDate()); err != nil {
+					sl.ReportError(entry.dueDate(), "DueDate", "DueDate", "date_format", doc.Options.DateFormat)
+				}
+			}
+		}
+	}, Document{})
+
 	return validate.Struct(d)
 }
@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Withholding define a tax withheld by the customer (ex IRPF), as percent or fixed amount.
+// It is computed on the net base and deducted from the payable amount.
+type Withholding struct {
+	Name    string `json:"name,omitempty"`    // Withholding name ex IRPF
+	Percent string `json:"percent,omitempty"` // Withholding in percent ex 15
+	Amount  string `json:"amount,omitempty"`  // Withholding in amount ex 123.40
+}
+
+func (w *Withholding) getWithholding() (string, decimal.Decimal) {
+	withholding := "0"
+	withholdingType := "percent"
+
+	if len(w.Percent) > 0 {
+		withholding = w.Percent
+	}
+
+	if len(w.Amount) > 0 {
+		withholding = w.Amount
+		withholdingType = "amount"
+	}
+
+	decVal, _ := decimal.NewFromString(withholding)
+
+	return withholdingType, decVal
+}
+
+// groupKey identify withholdings of the same name and rate
+func (w *Withholding) groupKey() string {
+	withholdingType, withholdingNumber := w.getWithholding()
+	if withholdingType == "amount" {
+		return w.Name + "|amount"
+	}
+
+	return w.Name + "|" + withholdingNumber.String()
+}